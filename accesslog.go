@@ -0,0 +1,165 @@
+package mux
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// AccessEntry describes a served request, see Router.AccessLogger
+type AccessEntry struct {
+	Time   time.Time
+	Method string
+	URI    string
+	Proto  string
+	Path   string
+	// Template is the path of the matched route, e.g. /user/:number
+	// It's empty if no route matches.
+	Template string
+	// Route is the name of the matched route.
+	Route     string
+	Vars      Vars
+	Status    int
+	Bytes     int64
+	Duration  time.Duration
+	// ClientIP is the host of the remote address or, behind trusted proxies,
+	// the last untrusted hop of X-Forwarded-For, see SetTrustedProxies.
+	ClientIP  string
+	Referer   string
+	UserAgent string
+}
+
+// AccessLogger logs the served requests.
+type AccessLogger interface {
+	Log(entry AccessEntry)
+}
+
+// AccessLoggerFunc is an adapter to use a func as AccessLogger
+type AccessLoggerFunc func(entry AccessEntry)
+
+// Log calls f(entry).
+func (f AccessLoggerFunc) Log(entry AccessEntry) {
+	f(entry)
+}
+
+// newAccessEntry returns the entry of the served request.
+func (r *Router) newAccessEntry(w *responseWriter, req *http.Request, start time.Time) AccessEntry {
+	entry := AccessEntry{
+		Time:      start,
+		Method:    req.Method,
+		URI:       req.RequestURI,
+		Proto:     req.Proto,
+		Path:      req.URL.Path,
+		Vars:      GetVars(req),
+		Status:    w.Status(),
+		Bytes:     w.Written(),
+		Duration:  time.Since(start),
+		ClientIP:  r.forwardedClientIP(req),
+		Referer:   req.Referer(),
+		UserAgent: req.UserAgent(),
+	}
+
+	if entry.URI == "" {
+		entry.URI = req.URL.RequestURI()
+	}
+
+	if route := CurrentRoute(req); route != nil {
+		entry.Template = route.GetPath()
+		if named, ok := route.(interface{ GetName() string }); ok {
+			entry.Route = named.GetName()
+		}
+	}
+
+	return entry
+}
+
+// clientIP returns the host of the remote address.
+func clientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
+
+// NewSlogAccessLogger returns an AccessLogger which emits one record per request.
+// Server errors (5xx) are logged with level error, all others with level info.
+func NewSlogAccessLogger(logger *slog.Logger) AccessLogger {
+	return AccessLoggerFunc(func(entry AccessEntry) {
+		level := slog.LevelInfo
+		if entry.Status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", entry.Method),
+			slog.String("path", entry.Path),
+			slog.String("template", entry.Template),
+			slog.String("route", entry.Route),
+			slog.Int("status", entry.Status),
+			slog.Int64("bytes", entry.Bytes),
+			slog.Duration("duration", entry.Duration),
+			slog.String("client_ip", entry.ClientIP),
+		}
+
+		if len(entry.Vars) != 0 {
+			vars := make([]interface{}, 0, len(entry.Vars))
+			for k, v := range entry.Vars {
+				vars = append(vars, slog.String(k, v))
+			}
+			attrs = append(attrs, slog.Group("vars", vars...))
+		}
+
+		logger.LogAttrs(context.Background(), level, "request", attrs...)
+	})
+}
+
+// NewCommonLogger returns an AccessLogger which writes the Common Log Format.
+//
+//     127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /user/42 HTTP/1.1" 200 2326
+func NewCommonLogger(w io.Writer) AccessLogger {
+	return &formatLogger{w: w}
+}
+
+// NewCombinedLogger returns an AccessLogger which writes the Combined Log Format,
+// the Common Log Format with referer and user agent.
+func NewCombinedLogger(w io.Writer) AccessLogger {
+	return &formatLogger{w: w, combined: true}
+}
+
+type formatLogger struct {
+	mu       sync.Mutex
+	w        io.Writer
+	combined bool
+}
+
+func (l *formatLogger) Log(entry AccessEntry) {
+	bytes := "-"
+	if entry.Bytes != 0 {
+		bytes = fmt.Sprint(entry.Bytes)
+	}
+
+	line := fmt.Sprintf("%s - - [%s] \"%s %s %s\" %d %s",
+		entry.ClientIP,
+		entry.Time.Format("02/Jan/2006:15:04:05 -0700"),
+		entry.Method,
+		entry.URI,
+		entry.Proto,
+		entry.Status,
+		bytes,
+	)
+
+	if l.combined {
+		line += fmt.Sprintf(" %q %q", entry.Referer, entry.UserAgent)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	io.WriteString(l.w, line+"\n")
+}
@@ -0,0 +1,182 @@
+package mux
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAccessLogger(t *testing.T) {
+
+	tests := []struct {
+		title    string
+		path     string
+		status   int
+		template string
+		route    string
+		vars     Vars
+		bytes    int64
+	}{
+		{
+			title:    "Matched route",
+			path:     "/user/42",
+			status:   http.StatusOK,
+			template: "/user/:number",
+			route:    "user.show",
+			vars:     Vars{":number": "42"},
+			bytes:    4,
+		},
+		{
+			title:  "Not found",
+			path:   "/article/42",
+			status: http.StatusNotFound,
+			bytes:  19,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			var entry AccessEntry
+			r := Classic()
+			r.AccessLogger = AccessLoggerFunc(func(e AccessEntry) {
+				entry = e
+			})
+
+			r.Get("/user/:number", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("user"))
+			}).(*Route).Name("user.show")
+
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost"+test.path, nil)
+			req.RemoteAddr = "10.0.0.1:5000"
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if entry.Status != test.status || entry.Template != test.template || entry.Route != test.route || entry.Bytes != test.bytes {
+				t.Errorf("Unexpected entry (%v)", entry)
+			}
+
+			if entry.Path != test.path || entry.ClientIP != "10.0.0.1" || entry.Method != http.MethodGet {
+				t.Errorf("Unexpected entry (%v)", entry)
+			}
+
+			if len(entry.Vars) != len(test.vars) || entry.Vars[":number"] != test.vars[":number"] {
+				t.Errorf("Unexpected vars (%v)", entry.Vars)
+			}
+		})
+	}
+}
+
+func TestAccessLoggerClientIP(t *testing.T) {
+
+	tests := []struct {
+		title        string
+		remoteAddr   string
+		forwardedFor []string
+		expectedIP   string
+	}{
+		{title: "Direct client", remoteAddr: "203.0.113.7:5000", forwardedFor: []string{"198.51.100.1"}, expectedIP: "203.0.113.7"},
+		{title: "Trusted proxy", remoteAddr: "10.0.0.1:5000", forwardedFor: []string{"198.51.100.1"}, expectedIP: "198.51.100.1"},
+		{title: "Spoofed hop", remoteAddr: "10.0.0.1:5000", forwardedFor: []string{"192.0.2.9, 198.51.100.1"}, expectedIP: "198.51.100.1"},
+		{title: "Chain of trusted proxies", remoteAddr: "10.0.0.1:5000", forwardedFor: []string{"198.51.100.1, 10.0.0.2", "10.0.0.3"}, expectedIP: "198.51.100.1"},
+		{title: "Invalid hop", remoteAddr: "10.0.0.1:5000", forwardedFor: []string{"unknown, 10.0.0.2"}, expectedIP: "10.0.0.2"},
+		{title: "Trusted proxy without header", remoteAddr: "10.0.0.1:5000", expectedIP: "10.0.0.1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			var entry AccessEntry
+			r := Classic()
+			r.SetTrustedProxies("10.0.0.0/8")
+			r.AccessLogger = AccessLoggerFunc(func(e AccessEntry) {
+				entry = e
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost/", nil)
+			req.RemoteAddr = test.remoteAddr
+			for _, value := range test.forwardedFor {
+				req.Header.Add("X-Forwarded-For", value)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if entry.ClientIP != test.expectedIP {
+				t.Errorf("Unexpected client IP (Expected: %s, Actucal: %s)", test.expectedIP, entry.ClientIP)
+			}
+		})
+	}
+}
+
+func TestSlogAccessLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := NewSlogAccessLogger(slog.New(slog.NewJSONHandler(buf, nil)))
+
+	logger.Log(AccessEntry{
+		Method:   http.MethodGet,
+		Path:     "/user/42",
+		Template: "/user/:number",
+		Route:    "user.show",
+		Vars:     Vars{":number": "42"},
+		Status:   http.StatusInternalServerError,
+		Bytes:    10,
+		ClientIP: "10.0.0.1",
+	})
+
+	record := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	if record["level"] != "ERROR" || record["msg"] != "request" || record["template"] != "/user/:number" || record["status"] != float64(500) {
+		t.Errorf("Unexpected record (%v)", record)
+	}
+
+	vars, ok := record["vars"].(map[string]interface{})
+	if !ok || vars[":number"] != "42" {
+		t.Errorf("Unexpected vars (%v)", record["vars"])
+	}
+}
+
+func TestFormatLogger(t *testing.T) {
+	entry := AccessEntry{
+		Time:      time.Date(2000, time.October, 10, 13, 55, 36, 0, time.FixedZone("", -7*60*60)),
+		Method:    http.MethodGet,
+		URI:       "/user/42?page=1",
+		Proto:     "HTTP/1.1",
+		Status:    http.StatusOK,
+		Bytes:     2326,
+		ClientIP:  "127.0.0.1",
+		Referer:   "http://localhost/",
+		UserAgent: "curl/7.0",
+	}
+
+	tests := []struct {
+		title    string
+		logger   func(buf *bytes.Buffer) AccessLogger
+		expected string
+	}{
+		{
+			title:    "Common",
+			logger:   func(buf *bytes.Buffer) AccessLogger { return NewCommonLogger(buf) },
+			expected: `127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /user/42?page=1 HTTP/1.1" 200 2326`,
+		},
+		{
+			title:    "Combined",
+			logger:   func(buf *bytes.Buffer) AccessLogger { return NewCombinedLogger(buf) },
+			expected: `127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET /user/42?page=1 HTTP/1.1" 200 2326 "http://localhost/" "curl/7.0"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			test.logger(buf).Log(entry)
+
+			if line := strings.TrimSuffix(buf.String(), "\n"); line != test.expected {
+				t.Errorf("Unexpected line (Expected: %s, Actucal: %s)", test.expected, line)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package mux
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BindErrors aggregates all errors of Bind, each error is a *ParamError.
+type BindErrors []error
+
+func (e BindErrors) Error() string {
+	messages := make([]string, len(e))
+	for k, err := range e {
+		messages[k] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// StatusCode returns the status code for the response (400 Bad Request).
+func (e BindErrors) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// bindTag is the parsed mux struct tag of a field.
+type bindTag struct {
+	source       string
+	name         string
+	defaultValue string
+	hasDefault   bool
+	required     bool
+	layout       string
+}
+
+func parseBindTag(tag string) (bindTag, error) {
+	bt := bindTag{layout: time.RFC3339}
+
+	for k, option := range strings.Split(tag, ",") {
+		key, value := option, ""
+		if idx := strings.Index(option, "="); idx != -1 {
+			key, value = option[:idx], option[idx+1:]
+		}
+
+		if k == 0 {
+			switch key {
+			case "path", "query", "header", "form":
+				bt.source, bt.name = key, value
+			default:
+				return bt, fmt.Errorf("mux: unknown bind source %q", key)
+			}
+			continue
+		}
+
+		switch key {
+		case "default":
+			bt.defaultValue, bt.hasDefault = value, true
+		case "required":
+			bt.required = true
+		case "layout":
+			bt.layout = value
+		default:
+			return bt, fmt.Errorf("mux: unknown bind option %q", key)
+		}
+	}
+
+	return bt, nil
+}
+
+// Bind fills the fields of the struct dst with the vars, queries, headers
+// and form values of the request. The fields are selected by the mux tag:
+//
+//     type listComments struct {
+//         ArticleID int64     `mux:"path=:number"`
+//         Page      int       `mux:"query=page,default=1"`
+//         Tags      []string  `mux:"query=tag"`
+//         Since     time.Time `mux:"query=since,layout=2006-01-02"`
+//         Tenant    string    `mux:"header=X-Tenant,required"`
+//         Comment   string    `mux:"form=comment"`
+//     }
+//
+// Path vars can be named with or without the leading colon or star. Slices get all
+// values, the queries are split at commas like GetQueries. Supported types are
+// strings, ints, uints, floats, bools, time.Time, time.Duration, UUID,
+// encoding.TextUnmarshaler and pointers/slices of them.
+//
+// All conversion errors are returned together as BindErrors. Errors of reading
+// the form are returned as they are, e.g. a *http.MaxBytesError (see ErrorStatus).
+func Bind(req *http.Request, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("mux: Bind expects a pointer to a struct, got %T", dst)
+	}
+
+	b := &binder{req: req}
+	if err := b.bindStruct(v.Elem()); err != nil {
+		return err
+	}
+
+	if b.formErr != nil {
+		return fmt.Errorf("mux: invalid form (%w)", b.formErr)
+	}
+
+	if len(b.errs) != 0 {
+		return b.errs
+	}
+
+	return nil
+}
+
+type binder struct {
+	req     *http.Request
+	queries queries
+	errs    BindErrors
+	formErr error
+}
+
+func (b *binder) bindStruct(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag, found := field.Tag.Lookup("mux")
+		if !found {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				if err := b.bindStruct(v.Field(i)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+
+		bt, err := parseBindTag(tag)
+		if err != nil {
+			return err
+		}
+
+		if bt.name == "" {
+			bt.name = field.Name
+		}
+
+		values := b.values(bt)
+		if len(values) == 0 {
+			switch {
+			case bt.hasDefault:
+				values = []string{bt.defaultValue}
+			case bt.required:
+				b.errs = append(b.errs, newParamError(bt.name, "", "", ErrParamMissing))
+				continue
+			default:
+				continue
+			}
+		}
+
+		if err := setField(v.Field(i), bt, values); err != nil {
+			b.errs = append(b.errs, err)
+		}
+	}
+
+	return nil
+}
+
+// values returns the raw values of the source of the tag.
+func (b *binder) values(bt bindTag) []string {
+	switch bt.source {
+	case "path":
+		vars := GetVars(b.req)
+		if value, found := vars[bt.name]; found {
+			return []string{value}
+		}
+		if value, found := vars[":"+bt.name]; found {
+			return []string{value}
+		}
+		if value, found := vars["*"+bt.name]; found {
+			return []string{value}
+		}
+	case "query":
+		if b.queries == nil {
+			b.queries = GetQueries(b.req)
+		}
+		if b.queries == nil {
+			b.queries, _ = extractQueries(b.req)
+		}
+		return b.queries[bt.name]
+	case "header":
+		return b.req.Header[http.CanonicalHeaderKey(bt.name)]
+	case "form":
+		if b.req.Form == nil {
+			b.formErr = b.req.ParseForm()
+		}
+		return b.req.Form[bt.name]
+	}
+
+	return nil
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	uuidType            = reflect.TypeOf(UUID{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func setField(field reflect.Value, bt bindTag, values []string) error {
+	if field.Kind() == reflect.Slice && field.Type() != reflect.TypeOf([]byte(nil)) {
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for k, value := range values {
+			if err := setValue(slice.Index(k), bt, value); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	return setValue(field, bt, values[0])
+}
+
+func setValue(field reflect.Value, bt bindTag, value string) error {
+	if field.Kind() == reflect.Ptr {
+		ptr := reflect.New(field.Type().Elem())
+		if err := setValue(ptr.Elem(), bt, value); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+
+	if field.CanAddr() && field.Addr().Type().Implements(textUnmarshalerType) && field.Type() != timeType {
+		if err := field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			return newParamError(bt.name, value, field.Type().String(), err)
+		}
+		return nil
+	}
+
+	switch field.Type() {
+	case timeType:
+		t, err := parseTime(bt.name, value, bt.layout)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return newParamError(bt.name, value, "duration", err)
+		}
+		field.SetInt(int64(d))
+		return nil
+	case uuidType:
+		u, err := parseUUID(bt.name, value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(u))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := parseBool(bt.name, value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return newParamError(bt.name, value, field.Kind().String(), err)
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return newParamError(bt.name, value, field.Kind().String(), err)
+		}
+		field.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return newParamError(bt.name, value, field.Kind().String(), err)
+		}
+		field.SetFloat(f)
+	default:
+		return newParamError(bt.name, value, field.Type().String(), fmt.Errorf("mux: unsupported bind type %s", field.Type()))
+	}
+
+	return nil
+}
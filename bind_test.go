@@ -0,0 +1,116 @@
+package mux
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type bindTestPagination struct {
+	Page  int `mux:"query=page,default=1"`
+	Limit int `mux:"query=limit,default=20"`
+}
+
+type bindTestRequest struct {
+	bindTestPagination
+	ArticleID int64         `mux:"path=number"`
+	Tags      []string      `mux:"query=tag"`
+	Since     time.Time     `mux:"query=since,layout=2006-01-02"`
+	Timeout   time.Duration `mux:"query=timeout"`
+	Draft     *bool         `mux:"query=draft"`
+	Tenant    string        `mux:"header=X-Tenant,required"`
+	Comment   string        `mux:"form=comment"`
+	Ignored   string
+}
+
+func TestBind(t *testing.T) {
+	r := Classic()
+
+	var dst bindTestRequest
+	var bindErr error
+	r.Post("/article/:number/comments", func(w http.ResponseWriter, req *http.Request) {
+		bindErr = Bind(req, &dst)
+	})
+
+	form := url.Values{"comment": {"Hello"}}
+	req := httptest.NewRequest(http.MethodPost, "/article/7/comments?tag=go,mux&tag=http&since=2017-07-20&timeout=5s&draft=true&page=3", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Tenant", "acme")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if bindErr != nil {
+		t.Fatalf("Unexpected error (%s)", bindErr.Error())
+	}
+
+	if dst.ArticleID != 7 || dst.Page != 3 || dst.Limit != 20 || dst.Tenant != "acme" || dst.Comment != "Hello" || dst.Timeout != 5*time.Second {
+		t.Errorf("Unexpected bound values (%+v)", dst)
+	}
+
+	if !reflect.DeepEqual(dst.Tags, []string{"go", "mux", "http"}) {
+		t.Errorf("Unexpected tags (%v)", dst.Tags)
+	}
+
+	if dst.Draft == nil || !*dst.Draft || dst.Since.Day() != 20 {
+		t.Errorf("Unexpected bound values (%+v)", dst)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/articles?page=first&since=yesterday", nil)
+
+	var dst bindTestRequest
+	err := Bind(req, &dst)
+
+	var errs BindErrors
+	if !errors.As(err, &errs) || len(errs) != 3 || errs.StatusCode() != http.StatusBadRequest {
+		t.Fatalf("Unexpected error (%v)", err)
+	}
+
+	names := []string{}
+	for _, err := range errs {
+		names = append(names, err.(*ParamError).Name)
+	}
+
+	if !reflect.DeepEqual(names, []string{"page", "since", "X-Tenant"}) {
+		t.Errorf("Unexpected invalid params (%v)", names)
+	}
+}
+
+func TestBindFail(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	tests := []struct {
+		title string
+		dst   interface{}
+	}{
+		{
+			title: "No pointer",
+			dst:   bindTestRequest{},
+		},
+		{
+			title: "Unknown source",
+			dst: &struct {
+				Page int `mux:"cookie=page"`
+			}{},
+		},
+		{
+			title: "Unknown option",
+			dst: &struct {
+				Page int `mux:"query=page,optional"`
+			}{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			if err := Bind(req, test.dst); err == nil {
+				t.Error("Expected a error")
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package mux
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// MaxBodyBytes limits the size of the request body, e.g. of an upload:
+//
+//     r.Post("/avatar", avatarHandler).(*mux.Route).MaxBodyBytes(1 << 20)
+//
+// Requests with a larger Content-Length are answered with 413 through the
+// ErrorHandler before the handler is called. Otherwise the body is wrapped with
+// http.MaxBytesReader, reads beyond the limit return a *http.MaxBytesError,
+// which handlers with an error (e.g. PostE) answer with 413.
+// A negative size disables the DefaultMaxBodyBytes of the router.
+func (r *Route) MaxBodyBytes(n int64) *Route {
+	if r.err == nil {
+		r.maxBodyBytes = n
+	}
+
+	return r
+}
+
+// GetMaxBodyBytes returns the body limit of the route or the DefaultMaxBodyBytes
+// of the router, zero if the body isn't limited.
+func (r *Route) GetMaxBodyBytes() int64 {
+	switch {
+	case r.maxBodyBytes < 0:
+		return 0
+	case r.maxBodyBytes == 0 && r.router != nil:
+		return r.router.DefaultMaxBodyBytes
+	}
+
+	return r.maxBodyBytes
+}
+
+// maxBodyHandler calls the handler with a limited body of the request.
+func (r *Router) maxBodyHandler(handler http.Handler, limit int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.ContentLength > limit {
+			r.handleError(w, req, NewHTTPError(http.StatusRequestEntityTooLarge, "", fmt.Errorf("mux: request body of %d bytes exceeds the limit of %d bytes", req.ContentLength, limit)))
+			return
+		}
+
+		if req.Body != nil && req.Body != http.NoBody {
+			limited := new(http.Request)
+			*limited = *req
+			limited.Body = http.MaxBytesReader(underlyingWriter(w), req.Body, limit)
+			req = limited
+		}
+
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// underlyingWriter returns the innermost writer of the wrappers, so
+// http.MaxBytesReader can tell the server to close the connection.
+func underlyingWriter(w http.ResponseWriter) http.ResponseWriter {
+	for {
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return w
+		}
+		w = unwrapper.Unwrap()
+	}
+}
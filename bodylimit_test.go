@@ -0,0 +1,86 @@
+package mux
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMaxBodyBytes(t *testing.T) {
+
+	tests := []struct {
+		title         string
+		path          string
+		body          string
+		contentLength int64
+		status        int
+		expected      string
+	}{
+		{title: "Body within limit", path: "/upload", body: "12345", contentLength: 5, status: http.StatusOK, expected: "5"},
+		{title: "Content-Length above limit", path: "/upload", body: "1234567890", contentLength: 10, status: http.StatusRequestEntityTooLarge},
+		{title: "Chunked body above limit", path: "/upload", body: "1234567890", contentLength: -1, status: http.StatusRequestEntityTooLarge},
+		{title: "Chunked form above limit", path: "/form", body: "comment=1234567890", contentLength: -1, status: http.StatusRequestEntityTooLarge},
+		{title: "Form within limit", path: "/form", body: "comment=1", contentLength: 9, status: http.StatusOK, expected: "1"},
+		{title: "Default limit", path: "/default", body: "123", contentLength: 3, status: http.StatusRequestEntityTooLarge},
+		{title: "Disabled limit", path: "/disabled", body: "1234567890", contentLength: 10, status: http.StatusOK, expected: "10"},
+	}
+
+	r := Classic()
+	r.DefaultMaxBodyBytes = 2
+
+	handler := func(w http.ResponseWriter, r *http.Request) error {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+
+		w.Write([]byte(strconv.Itoa(len(body))))
+		return nil
+	}
+
+	r.PostE("/upload", handler).(*Route).MaxBodyBytes(8)
+	r.PostE("/default", handler)
+	r.PostE("/disabled", handler).(*Route).MaxBodyBytes(-1)
+	r.PostE("/form", func(w http.ResponseWriter, r *http.Request) error {
+		var dst struct {
+			Comment string `mux:"form=comment"`
+		}
+		if err := Bind(r, &dst); err != nil {
+			return err
+		}
+
+		w.Write([]byte(dst.Comment))
+		return nil
+	}).(*Route).MaxBodyBytes(16)
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "http://localhost"+test.path, strings.NewReader(test.body))
+			req.ContentLength = test.contentLength
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Code != test.status {
+				t.Errorf("Unexpected status code (Expected: %d, Actucal: %d)", test.status, res.Code)
+			}
+
+			if test.expected != "" && res.Body.String() != test.expected {
+				t.Errorf("Unexpected body (Expected: %s, Actucal: %s)", test.expected, res.Body.String())
+			}
+		})
+	}
+
+	limits := map[string]int64{}
+	r.Walk(func(method string, route RouteInterface) error {
+		limits[route.GetPath()] = route.(*Route).GetMaxBodyBytes()
+		return nil
+	})
+
+	if limits["/form"] != 16 || limits["/upload"] != 8 || limits["/default"] != 2 || limits["/disabled"] != 0 {
+		t.Errorf("Unexpected limits (%v)", limits)
+	}
+}
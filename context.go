@@ -13,6 +13,10 @@ const (
 	queriesKey contextKey = iota
 	routeKey
 	varsKey
+	typedVarsKey
+	spanKey
+	schemeKey
+	originalURLKey
 )
 
 // GetQueries returns the query variables for the current request.
@@ -61,6 +65,96 @@ func AddVars(r *http.Request, val interface{}) *http.Request {
 	return contextSet(r, varsKey, val)
 }
 
+// GetTypedVars returns the parsed route variables for the current request, if any.
+// Vars of routes without a parsing param type are strings, see Router.RegisterParamType
+func GetTypedVars(r *http.Request) TypedVars {
+	if rv := contextGet(r, typedVarsKey); rv != nil {
+		return rv.(TypedVars)
+	}
+
+	if route, ok := CurrentRoute(r).(*Route); ok && len(route.varTypes) != 0 {
+		if typed, err := route.ExtractTypedVars(r); err == nil {
+			return typed
+		}
+	}
+
+	vars := GetVars(r)
+	if vars == nil {
+		return nil
+	}
+
+	typed := make(TypedVars, len(vars))
+	for k, v := range vars {
+		typed[k] = v
+	}
+	return typed
+}
+
+func AddTypedVars(r *http.Request, val interface{}) *http.Request {
+	return contextSet(r, typedVarsKey, val)
+}
+
+// GetSpan returns the tracing span of the current request, if any.
+// See Router.SpanExporter
+func GetSpan(r *http.Request) *Span {
+	if rv := contextGet(r, spanKey); rv != nil {
+		return rv.(*Span)
+	}
+	return nil
+}
+
+func AddSpan(r *http.Request, val *Span) *http.Request {
+	if val == nil {
+		return r
+	}
+
+	return contextSet(r, spanKey, val)
+}
+
+// GetScheme returns the scheme of the current request. The URL of server
+// requests has no scheme, then it's the scheme forwarded by a trusted proxy
+// (see Router.SetTrustedProxies), https for TLS connections or http.
+func GetScheme(r *http.Request) string {
+	if rv := contextGet(r, schemeKey); rv != nil {
+		return rv.(string)
+	}
+
+	if r.URL.Scheme != "" {
+		return strings.ToLower(r.URL.Scheme)
+	}
+
+	if r.TLS != nil {
+		return "https"
+	}
+
+	return "http"
+}
+
+func AddScheme(r *http.Request, val string) *http.Request {
+	return contextSet(r, schemeKey, val)
+}
+
+// OriginalURL returns the URL of the request as it was received by the router,
+// before it was rewritten, lowercased or the prefix of Mount was stripped.
+// The URL is only stored if the router changed it, otherwise it's r.URL.
+func OriginalURL(r *http.Request) *url.URL {
+	if rv := contextGet(r, originalURLKey); rv != nil {
+		return rv.(*url.URL)
+	}
+	return r.URL
+}
+
+// AddOriginalURL stores the URL, an already stored URL is kept.
+func AddOriginalURL(r *http.Request, val *url.URL) *http.Request {
+	if contextGet(r, originalURLKey) != nil {
+		return r
+	}
+
+	u := new(url.URL)
+	*u = *val
+	return contextSet(r, originalURLKey, u)
+}
+
 func contextGet(r *http.Request, key interface{}) interface{} {
 	return r.Context().Value(key)
 }
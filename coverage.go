@@ -0,0 +1,238 @@
+package mux
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// CoverageRecorder counts how often the registered routes and their
+// matchers were hit while the router is running.
+//
+//     r := mux.Classic()
+//     coverage := r.EnableCoverage()
+//     r.Get("/debug/coverage", coverage.ServeHTTP)
+//
+// The counts can be dumped as JSON with WriteJSON/WriteFile (e.g. at shutdown)
+// or with the recorder itself, which is a http.Handler.
+type CoverageRecorder struct {
+	router   *Router
+	mu       sync.Mutex
+	routes   map[RouteInterface]*routeCoverage
+	notFound int
+}
+
+type routeCoverage struct {
+	hits     int
+	matched  map[interface{}]int
+	rejected map[interface{}]int
+}
+
+// CoverageReport is the JSON representation of the recorded counts.
+type CoverageReport struct {
+	Routes   []RouteCoverage `json:"routes"`
+	NotFound int             `json:"notFound"`
+}
+
+// RouteCoverage holds the counts of a single route.
+type RouteCoverage struct {
+	Method   string            `json:"method"`
+	Path     string            `json:"path"`
+	Name     string            `json:"name,omitempty"`
+	Hits     int               `json:"hits"`
+	Matchers []MatcherCoverage `json:"matchers"`
+}
+
+// MatcherCoverage holds how often a matcher of a route matched or rejected a request.
+type MatcherCoverage struct {
+	Matcher  string `json:"matcher"`
+	Matched  int    `json:"matched"`
+	Rejected int    `json:"rejected"`
+}
+
+// EnableCoverage switches the router into the coverage mode and
+// returns the recorder. Calling it again returns the same recorder.
+// Only requests served by the router are recorded, Router.Match isn't.
+//
+// In coverage mode the matchers of a rejecting route are evaluated
+// a second time to find the matcher which rejected the request.
+func (r *Router) EnableCoverage() *CoverageRecorder {
+	if r.coverage == nil {
+		r.coverage = &CoverageRecorder{
+			router: r,
+			routes: map[RouteInterface]*routeCoverage{},
+		}
+	}
+
+	return r.coverage
+}
+
+func (c *CoverageRecorder) entry(route RouteInterface) *routeCoverage {
+	entry, found := c.routes[route]
+	if !found {
+		entry = &routeCoverage{
+			matched:  map[interface{}]int{},
+			rejected: map[interface{}]int{},
+		}
+		c.routes[route] = entry
+	}
+
+	return entry
+}
+
+// recordAttempt records the outcome of matching the route against the request.
+func (c *CoverageRecorder) recordAttempt(route RouteInterface, req *http.Request, matched bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.entry(route)
+	for _, m := range route.GetMatchers() {
+		key := matcherKey(m)
+		if matched || m.Match(req) {
+			entry.matched[key]++
+			continue
+		}
+
+		entry.rejected[key]++
+		break
+	}
+
+	if matched {
+		entry.hits++
+	}
+}
+
+func (c *CoverageRecorder) recordNotFound() {
+	c.mu.Lock()
+	c.notFound++
+	c.mu.Unlock()
+}
+
+// Hits returns how often the route matched a served request.
+func (c *CoverageRecorder) Hits(route RouteInterface) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, found := c.routes[route]; found {
+		return entry.hits
+	}
+
+	return 0
+}
+
+// Report returns the counts of all registered routes.
+func (c *CoverageRecorder) Report() CoverageReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := CoverageReport{
+		Routes:   make([]RouteCoverage, 0),
+		NotFound: c.notFound,
+	}
+
+	c.router.Walk(func(method string, route RouteInterface) error {
+		entry := c.entry(route)
+
+		rc := RouteCoverage{
+			Method:   method,
+			Path:     route.GetPath(),
+			Hits:     entry.hits,
+			Matchers: make([]MatcherCoverage, 0, len(route.GetMatchers())),
+		}
+
+		if named, ok := route.(interface{ GetName() string }); ok {
+			rc.Name = named.GetName()
+		}
+
+		for _, m := range route.GetMatchers() {
+			key := matcherKey(m)
+			rc.Matchers = append(rc.Matchers, MatcherCoverage{
+				Matcher:  matcherName(m),
+				Matched:  entry.matched[key],
+				Rejected: entry.rejected[key],
+			})
+		}
+
+		report.Routes = append(report.Routes, rc)
+		return nil
+	})
+
+	return report
+}
+
+// Unexercised returns the counts of all routes which were never hit.
+func (c *CoverageRecorder) Unexercised() []RouteCoverage {
+	unexercised := make([]RouteCoverage, 0)
+	for _, rc := range c.Report().Routes {
+		if rc.Hits == 0 {
+			unexercised = append(unexercised, rc)
+		}
+	}
+
+	return unexercised
+}
+
+// WriteJSON writes the report as JSON.
+func (c *CoverageRecorder) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(c.Report())
+}
+
+// WriteFile writes the report as JSON into the named file.
+func (c *CoverageRecorder) WriteFile(name string) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := c.WriteJSON(file); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// ServeHTTP writes the report as JSON.
+func (c *CoverageRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", mediaTypeJSON)
+	c.WriteJSON(w)
+}
+
+// matcherID identifies a matcher which isn't comparable, e.g. a map or a func.
+type matcherID struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// matcherKey returns the identity of the matcher, the counts stay with their
+// matcher if SortRoutes reorders the matchers of a route.
+func matcherKey(m Matcher) interface{} {
+	v := reflect.ValueOf(m)
+	switch v.Kind() {
+	case reflect.Map, reflect.Func, reflect.Ptr, reflect.Slice:
+		return matcherID{typ: v.Type(), ptr: v.Pointer()}
+	}
+
+	if v.Type().Comparable() {
+		return m
+	}
+
+	return matcherID{typ: v.Type()}
+}
+
+// matcherName returns the type name of the matcher without the package.
+func matcherName(m Matcher) string {
+	name := fmt.Sprintf("%T", m)
+	if idx := strings.LastIndex(name, "."); idx != -1 {
+		name = name[idx+1:]
+	}
+
+	return name
+}
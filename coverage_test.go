@@ -0,0 +1,79 @@
+package mux
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCoverageRecorder(t *testing.T) {
+	r := Classic()
+	testHandler := func(w http.ResponseWriter, r *http.Request) {}
+
+	coverage := r.EnableCoverage()
+	if r.EnableCoverage() != coverage {
+		t.Error("Unexpected new coverage recorder")
+	}
+
+	r.Get("/api/user/:number", testHandler).(*Route).Name("user.show").Headers("X-Tenant", "acme")
+	r.Get("/api/echo", testHandler)
+	r.Get("/debug/coverage", coverage.ServeHTTP)
+
+	requests := []struct {
+		path   string
+		header string
+	}{
+		{path: "/api/user/1", header: "acme"},
+		{path: "/api/user/2", header: "acme"},
+		{path: "/api/user/3", header: "other"},
+		{path: "/api/unknown"},
+	}
+
+	for _, request := range requests {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost"+request.path, nil)
+		req.Header.Set("X-Tenant", request.header)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if r.Match(httptest.NewRequest(http.MethodGet, "http://localhost/api/echo", nil)) == nil {
+		t.Fatal("Expected a matched route")
+	}
+
+	r.SortRoutes()
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/debug/coverage", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	report := CoverageReport{}
+	if err := json.Unmarshal(res.Body.Bytes(), &report); err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	if len(report.Routes) != 3 || report.NotFound != 2 {
+		t.Fatalf("Unexpected report (%v)", report)
+	}
+
+	show := report.Routes[0]
+	if show.Name != "user.show" || show.Hits != 2 || len(show.Matchers) != 2 {
+		t.Fatalf("Unexpected route coverage (%v)", show)
+	}
+
+	// the header matcher is sorted first, it rejected the request of the report too
+	header := show.Matchers[0]
+	if header.Matcher != "headerMatcher" || header.Matched != 2 || header.Rejected != 2 {
+		t.Errorf("Unexpected matcher coverage (%v)", header)
+	}
+
+	unexercised := coverage.Unexercised()
+	if len(unexercised) != 1 || unexercised[0].Path != "/api/echo" {
+		t.Errorf("Unexpected unexercised routes (%v)", unexercised)
+	}
+
+	var content bytes.Buffer
+	if err := coverage.WriteJSON(&content); err != nil || content.Len() == 0 {
+		t.Errorf("Unexpected error (%v)", err)
+	}
+}
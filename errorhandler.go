@@ -0,0 +1,141 @@
+package mux
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+)
+
+// HandlerFuncE is a handler which returns an error instead of writing
+// the error response itself, see Router.ErrorHandler
+type HandlerFuncE func(http.ResponseWriter, *http.Request) error
+
+// statusCoder is implemented by errors which know their status code,
+// e.g. HTTPError, ParamError and BindErrors
+type statusCoder interface {
+	StatusCode() int
+}
+
+// ErrorStatus returns the status code of the error, errors without a valid
+// status code are internal server errors. A *http.MaxBytesError of a body
+// limit (see Route.MaxBodyBytes) is 413.
+func ErrorStatus(err error) int {
+	var sc statusCoder
+	if errors.As(err, &sc) {
+		if status := sc.StatusCode(); status >= 100 && status <= 599 {
+			return status
+		}
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	return http.StatusInternalServerError
+}
+
+// ErrorMessage returns the public message of the error. Internal server
+// errors only show the status text, unless they are a HTTPError with a message.
+func ErrorMessage(err error) string {
+	var he *HTTPError
+	if errors.As(err, &he) && he.Message != "" {
+		return he.Message
+	}
+
+	status := ErrorStatus(err)
+	if status >= http.StatusInternalServerError || he != nil {
+		return http.StatusText(status)
+	}
+
+	return err.Error()
+}
+
+// errorDetails returns the messages of aggregated errors, e.g. BindErrors
+func errorDetails(err error) []string {
+	var errs BindErrors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+
+	details := make([]string, len(errs))
+	for k, err := range errs {
+		details[k] = err.Error()
+	}
+
+	return details
+}
+
+// DefaultErrorHandler writes the error as plain text.
+func DefaultErrorHandler(w http.ResponseWriter, req *http.Request, err error) {
+	http.Error(w, ErrorMessage(err), ErrorStatus(err))
+}
+
+// JSONErrorHandler writes the error as JSON:
+//
+//     {"status": 400, "message": "...", "errors": ["..."]}
+func JSONErrorHandler(w http.ResponseWriter, req *http.Request, err error) {
+	status := ErrorStatus(err)
+
+	w.Header().Set("Content-Type", mediaTypeJSON)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Status  int      `json:"status"`
+		Message string   `json:"message"`
+		Errors  []string `json:"errors,omitempty"`
+	}{
+		Status:  status,
+		Message: ErrorMessage(err),
+		Errors:  errorDetails(err),
+	})
+}
+
+// HTMLErrorHandler writes the error as simple HTML page.
+func HTMLErrorHandler(w http.ResponseWriter, req *http.Request, err error) {
+	status := ErrorStatus(err)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html><head><title>%d %s</title></head><body><h1>%d %s</h1><p>%s</p></body></html>\n",
+		status, html.EscapeString(http.StatusText(status)),
+		status, html.EscapeString(http.StatusText(status)),
+		html.EscapeString(ErrorMessage(err)))
+}
+
+// handleError writes the error with the configured ErrorHandler.
+func (r *Router) handleError(w http.ResponseWriter, req *http.Request, err error) {
+	switch {
+	case r.ErrorHandler != nil:
+		r.ErrorHandler(w, req, err)
+	case r.ProblemDetails && acceptsProblem(req):
+		ProblemErrorHandler(w, req, err)
+	default:
+		DefaultErrorHandler(w, req, err)
+	}
+}
+
+// handlerFuncE converts the handler into a handler func which passes
+// returned errors to the ErrorHandler. Errors returned after the handler
+// wrote the header are only logged, the response can't be changed anymore.
+func (r *Router) handlerFuncE(handler HandlerFuncE) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		rw := newResponseWriter(w)
+
+		err := handler(rw, req)
+		if err == nil {
+			return
+		}
+
+		if rw.wroteHeader {
+			log.Printf("mux: error after the response of %s %s was written: %v", req.Method, req.URL.Path, err)
+			return
+		}
+
+		r.handleError(rw, req, err)
+	}
+}
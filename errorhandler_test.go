@@ -0,0 +1,178 @@
+package mux
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestErrorStatusAndMessage(t *testing.T) {
+
+	tests := []struct {
+		title   string
+		err     error
+		status  int
+		message string
+	}{
+		{
+			title:   "Internal error",
+			err:     errors.New("database is down"),
+			status:  http.StatusInternalServerError,
+			message: "Internal Server Error",
+		},
+		{
+			title:   "HTTP error with message",
+			err:     NewHTTPError(http.StatusConflict, "User already exists", errors.New("duplicate key")),
+			status:  http.StatusConflict,
+			message: "User already exists",
+		},
+		{
+			title:   "HTTP error without message",
+			err:     NewHTTPError(http.StatusForbidden, "", errors.New("missing role")),
+			status:  http.StatusForbidden,
+			message: "Forbidden",
+		},
+		{
+			title:   "Wrapped param error",
+			err:     fmt.Errorf("load user: %w", newParamError("id", "abc", "int64", errors.New("invalid syntax"))),
+			status:  http.StatusBadRequest,
+			message: `load user: mux: param "id": "abc" is not a valid int64`,
+		},
+		{
+			title:   "HTTP error without status",
+			err:     &HTTPError{Cause: errors.New("no status")},
+			status:  http.StatusInternalServerError,
+			message: "Internal Server Error",
+		},
+		{
+			title:   "HTTP error with invalid status",
+			err:     NewHTTPError(42, "", nil),
+			status:  http.StatusInternalServerError,
+			message: "Internal Server Error",
+		},
+		{
+			title:   "Wrapped body limit error",
+			err:     fmt.Errorf("read upload: %w", &http.MaxBytesError{Limit: 8}),
+			status:  http.StatusRequestEntityTooLarge,
+			message: "read upload: http: request body too large",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			if status := ErrorStatus(test.err); status != test.status {
+				t.Errorf("Unexpected status (%d)", status)
+			}
+
+			if message := ErrorMessage(test.err); message != test.message {
+				t.Errorf("Unexpected message (%s)", message)
+			}
+		})
+	}
+}
+
+func TestRouteWithErrorHandler(t *testing.T) {
+
+	tests := []struct {
+		title        string
+		errorHandler func(http.ResponseWriter, *http.Request, error)
+		contentType  string
+		body         string
+	}{
+		{
+			title:       "Default",
+			contentType: "text/plain; charset=utf-8",
+			body:        "User not found\n",
+		},
+		{
+			title:        "JSON",
+			errorHandler: JSONErrorHandler,
+			contentType:  mediaTypeJSON,
+			body:         `{"status":404,"message":"User not found"}` + "\n",
+		},
+		{
+			title:        "HTML",
+			errorHandler: HTMLErrorHandler,
+			contentType:  "text/html; charset=utf-8",
+			body:         "<p>User not found</p>",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			r := Classic()
+			r.ErrorHandler = test.errorHandler
+			r.GetE("/user/:number", func(w http.ResponseWriter, req *http.Request) error {
+				return NewHTTPError(http.StatusNotFound, "User not found", nil)
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost/user/1", nil)
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Code != http.StatusNotFound || res.Header().Get("Content-Type") != test.contentType || !strings.Contains(res.Body.String(), test.body) {
+				t.Errorf("Unexpected response (Status code: %d, Body: %s)", res.Code, res.Body.String())
+			}
+		})
+	}
+}
+
+func TestJSONErrorHandlerWithDetails(t *testing.T) {
+	err := BindErrors{
+		newParamError("page", "", "", ErrParamMissing),
+		newParamError("limit", "ten", "int", errors.New("invalid syntax")),
+	}
+
+	res := httptest.NewRecorder()
+	JSONErrorHandler(res, httptest.NewRequest(http.MethodGet, "/", nil), err)
+
+	body := struct {
+		Status int      `json:"status"`
+		Errors []string `json:"errors"`
+	}{}
+
+	if err := json.Unmarshal(res.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	if body.Status != http.StatusBadRequest || len(body.Errors) != 2 {
+		t.Errorf("Unexpected body (%s)", res.Body.String())
+	}
+}
+
+func TestRouteWithoutError(t *testing.T) {
+	r := Classic()
+	r.PostE("/user", func(w http.ResponseWriter, req *http.Request) error {
+		w.WriteHeader(http.StatusCreated)
+		return nil
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost/user", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusCreated {
+		t.Errorf("Unexpected status code (%d)", res.Code)
+	}
+}
+
+func TestRouteWithErrorAfterWrite(t *testing.T) {
+	r := Classic()
+	r.ErrorHandler = JSONErrorHandler
+	r.GetE("/user", func(w http.ResponseWriter, req *http.Request) error {
+		w.Write([]byte("partial"))
+		return errors.New("connection lost")
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/user", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK || res.Body.String() != "partial" {
+		t.Errorf("Unexpected response (Status code: %d, Body: %s)", res.Code, res.Body.String())
+	}
+}
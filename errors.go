@@ -1,6 +1,9 @@
 package mux
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // BadRouteError creates error for a bad route
 type BadRouteError struct {
@@ -42,3 +45,53 @@ func (bme *BadPathError) Error() string { return fmt.Sprintf("Path is invaild (%
 func NewBadPathError(text string) error {
 	return &BadPathError{s: text}
 }
+
+// HTTPError creates error with a status code and a public message,
+// see Router.ErrorHandler
+type HTTPError struct {
+	// Status code of the response
+	Status int
+	// Message shown to the client, the status text is used if it's empty
+	Message string
+	// Cause is the internal error, it's never shown to the client
+	Cause error
+}
+
+// NewHTTPError returns an error with a status code and a public message.
+func NewHTTPError(status int, message string, cause error) *HTTPError {
+	return &HTTPError{
+		Status:  status,
+		Message: message,
+		Cause:   cause,
+	}
+}
+
+func (he *HTTPError) Error() string {
+	if he.Cause != nil {
+		return fmt.Sprintf("HTTP error %d (%s): %v", he.Status, he.Message, he.Cause)
+	}
+
+	return fmt.Sprintf("HTTP error %d (%s)", he.Status, he.Message)
+}
+
+// Unwrap returns the cause.
+func (he *HTTPError) Unwrap() error {
+	return he.Cause
+}
+
+// StatusCode returns the status code of the response.
+func (he *HTTPError) StatusCode() int {
+	return he.Status
+}
+
+// RouteErrors aggregates the errors of the registered routes, see Router.HasErrors
+type RouteErrors []error
+
+func (re RouteErrors) Error() string {
+	messages := make([]string, len(re))
+	for k, err := range re {
+		messages[k] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
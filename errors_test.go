@@ -1,6 +1,8 @@
 package mux
 
 import (
+	"errors"
+	"net/http"
 	"strings"
 	"testing"
 )
@@ -25,3 +27,19 @@ func TestBadRouteError(t *testing.T) {
 		t.Errorf("Error message is bad (%s)", err.Error())
 	}
 }
+
+func TestHTTPError(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := NewHTTPError(http.StatusServiceUnavailable, "Try again later", cause)
+
+	if !errors.Is(err, cause) || err.StatusCode() != http.StatusServiceUnavailable || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("Error is bad (%s)", err.Error())
+	}
+}
+
+func TestRouteErrors(t *testing.T) {
+	err := RouteErrors{NewBadPathError("/echo"), NewBadMethodError("GGET")}
+	if err.Error() != "Path is invaild (/echo); Method not vaild (GGET)" {
+		t.Errorf("Error message is bad (%s)", err.Error())
+	}
+}
@@ -0,0 +1,328 @@
+package mux
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultFingerprintPattern matches file names with a content hash,
+// e.g. app.3f2a9c1b.js or app-3f2a9c1b.css
+var DefaultFingerprintPattern = regexp.MustCompile(`[.-][0-9a-fA-F]{8,}\.[^/]+$`)
+
+// FileServerOptions configures the FileServer
+type FileServerOptions struct {
+	// This defines the flag for listings of directories without index file.
+	DirectoryListing bool
+	// Index file of directories, index.html if empty.
+	Index string
+	// This defines the flag for single page applications, the index
+	// file of the root is served for all unknown paths without an extension.
+	// Unknown files like /app.js are not found.
+	SPA bool
+	// Files matching the pattern are cached as immutable,
+	// DefaultFingerprintPattern if nil.
+	FingerprintPattern *regexp.Regexp
+}
+
+// precompressedEncodings are the content encodings of sibling files in order of preference.
+var precompressedEncodings = []struct {
+	encoding  string
+	extension string
+}{
+	{encoding: "br", extension: ".br"},
+	{encoding: "gzip", extension: ".gz"},
+}
+
+// ServeFiles registers GET and HEAD routes which serve the files of fsys,
+// e.g. a os.DirFS or an embed.FS. The path must end with a wildcard:
+//
+//     //go:embed assets
+//     var assets embed.FS
+//
+//     sub, _ := fs.Sub(assets, "assets")
+//     r.ServeFiles("/assets/*filepath", sub)
+//
+// Files are resolved with the case of the requested path, also if the router
+// isn't CaseSensitiveURL. It returns the GET route, see FileServer for the served headers.
+func (r *Router) ServeFiles(path string, fsys fs.FS) RouteInterface {
+	return r.ServeFilesWithOptions(path, fsys, FileServerOptions{})
+}
+
+// ServeFilesWithOptions is like ServeFiles with the options of the FileServer.
+func (r *Router) ServeFilesWithOptions(path string, fsys fs.FS, opts FileServerOptions) RouteInterface {
+	handler := newFileServer(fsys, opts)
+	handler.router = r
+
+	route := r.Handle(http.MethodGet, path, handler)
+	headRoute := r.Handle(http.MethodHead, path, handler)
+
+	if !containsWildcard(path) {
+		for _, rt := range []RouteInterface{route, headRoute} {
+			if !rt.HasError() {
+				rt.SetError(NewBadRouteError(rt, "path of files has to end with a wildcard, e.g. /assets/*filepath"))
+			}
+		}
+	}
+
+	return route
+}
+
+// FileServer returns a handler which serves the files of fsys.
+// The file is the wildcard var of the matched route or the path of the request.
+//
+// Directories are served with their index file, precompressed siblings
+// (.br, .gz) are served if the client accepts the encoding. The responses have
+// an ETag of the content and a Last-Modified header if the modification time
+// is known, fingerprinted files are cached as immutable.
+func FileServer(fsys fs.FS, opts FileServerOptions) http.Handler {
+	return newFileServer(fsys, opts)
+}
+
+type fileServer struct {
+	fsys   fs.FS
+	opts   FileServerOptions
+	router *Router
+	mu     sync.Mutex
+	etags  map[fileVersion]string
+}
+
+// fileVersion identifies the content of a file to cache its ETag.
+type fileVersion struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func newFileServer(fsys fs.FS, opts FileServerOptions) *fileServer {
+	if opts.Index == "" {
+		opts.Index = "index.html"
+	}
+
+	if opts.FingerprintPattern == nil {
+		opts.FingerprintPattern = DefaultFingerprintPattern
+	}
+
+	return &fileServer{
+		fsys:  fsys,
+		opts:  opts,
+		etags: map[fileVersion]string{},
+	}
+}
+
+func (f *fileServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	name := req.URL.Path
+	if route, ok := CurrentRoute(req).(*Route); ok && route.wildcard != "" {
+		name = GetVars(req).Get(route.wildcard)
+	}
+	name = originalCase(req, name)
+
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if name == "" {
+		name = "."
+	}
+
+	if !f.serve(w, req, name) {
+		if !f.opts.SPA || path.Ext(name) != "" || !f.serve(w, req, f.opts.Index) {
+			f.notFound(w, req)
+		}
+	}
+}
+
+// originalCase returns the name with the case of the original path of the request,
+// the router matches lower case paths unless it's CaseSensitiveURL.
+func originalCase(req *http.Request, name string) string {
+	original := OriginalURL(req).Path
+	if len(original) < len(name) {
+		return name
+	}
+
+	if suffix := original[len(original)-len(name):]; strings.EqualFold(suffix, name) {
+		return suffix
+	}
+
+	return name
+}
+
+// serve serves the file or the directory, it returns false if it doesn't exist.
+func (f *fileServer) serve(w http.ResponseWriter, req *http.Request, name string) bool {
+	info, err := fs.Stat(f.fsys, name)
+	if err != nil {
+		return false
+	}
+
+	if info.IsDir() {
+		index := path.Join(name, f.opts.Index)
+		if indexInfo, err := fs.Stat(f.fsys, index); err == nil && !indexInfo.IsDir() {
+			return f.serveFile(w, req, index, indexInfo)
+		}
+
+		if !f.opts.DirectoryListing {
+			return false
+		}
+
+		f.serveDirectory(w, req, name)
+		return true
+	}
+
+	return f.serveFile(w, req, name, info)
+}
+
+func (f *fileServer) serveFile(w http.ResponseWriter, req *http.Request, name string, info fs.FileInfo) bool {
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	servedName, servedInfo, encoding := name, info, ""
+	for _, precompressed := range precompressedEncodings {
+		if !acceptsEncoding(req, precompressed.encoding) {
+			continue
+		}
+
+		if siblingInfo, err := fs.Stat(f.fsys, name+precompressed.extension); err == nil && !siblingInfo.IsDir() {
+			servedName, servedInfo, encoding = name+precompressed.extension, siblingInfo, precompressed.encoding
+			break
+		}
+	}
+
+	file, err := f.fsys.Open(servedName)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	w.Header().Add("Vary", "Accept-Encoding")
+	if encoding != "" {
+		w.Header().Set("Content-Encoding", encoding)
+	}
+
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(file)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return true
+		}
+		content = bytes.NewReader(data)
+	}
+
+	etag, err := f.etag(servedName, servedInfo, content)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return true
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", etag)
+	if f.opts.FingerprintPattern.MatchString(name) {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	}
+
+	http.ServeContent(w, req, name, servedInfo.ModTime(), content)
+	return true
+}
+
+// etag returns the cached ETag of the content or calculates it.
+func (f *fileServer) etag(name string, info fs.FileInfo, content io.ReadSeeker) (string, error) {
+	version := fileVersion{name: name, size: info.Size(), modTime: info.ModTime()}
+
+	f.mu.Lock()
+	etag, found := f.etags[version]
+	f.mu.Unlock()
+
+	if found {
+		return etag, nil
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	etag = `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+
+	f.mu.Lock()
+	f.etags[version] = etag
+	f.mu.Unlock()
+
+	return etag, nil
+}
+
+func (f *fileServer) serveDirectory(w http.ResponseWriter, req *http.Request, name string) {
+	entries, err := fs.ReadDir(f.fsys, name)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	base := req.URL.Path
+	if !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<pre>\n")
+	for _, entry := range entries {
+		entryName := entry.Name()
+		if entry.IsDir() {
+			entryName += "/"
+		}
+
+		link := url.URL{Path: base + entryName}
+		fmt.Fprintf(w, "<a href=\"%s\">%s</a>\n", link.String(), html.EscapeString(entryName))
+	}
+	fmt.Fprintf(w, "</pre>\n")
+}
+
+func (f *fileServer) notFound(w http.ResponseWriter, req *http.Request) {
+	if f.router != nil {
+		f.router.notFoundHandler().ServeHTTP(w, req)
+		return
+	}
+
+	http.NotFound(w, req)
+}
+
+// acceptsEncoding returns true if the Accept-Encoding header of the request
+// contains the encoding with a quality above zero.
+func acceptsEncoding(req *http.Request, encoding string) bool {
+	for _, part := range strings.Split(req.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), encoding) {
+			continue
+		}
+
+		if _, value, found := strings.Cut(params, "q="); found {
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && q == 0 {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,210 @@
+package mux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+var testFiles = fstest.MapFS{
+	"index.html":             {Data: []byte("<h1>index</h1>"), ModTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+	"app.3f2a9c1b.js":        {Data: []byte("console.log('app')")},
+	"style.css":              {Data: []byte("body {}")},
+	"style.css.gz":           {Data: []byte("gzip")},
+	"style.css.br":           {Data: []byte("brotli")},
+	"docs/readme.txt":        {Data: []byte("readme")},
+	"images/logo.svg":        {Data: []byte("<svg></svg>")},
+	"images/icons/arrow.svg": {Data: []byte("<svg></svg>")},
+	"Logo.PNG":               {Data: []byte("png")},
+}
+
+func TestServeFiles(t *testing.T) {
+
+	tests := []struct {
+		title          string
+		path           string
+		header         map[string]string
+		opts           FileServerOptions
+		statusCode     int
+		body           string
+		expectedHeader map[string]string
+	}{
+		{
+			title:          "File",
+			path:           "/assets/docs/readme.txt",
+			statusCode:     http.StatusOK,
+			body:           "readme",
+			expectedHeader: map[string]string{"Content-Type": "text/plain; charset=utf-8"},
+		},
+		{
+			title:          "Index",
+			path:           "/assets/",
+			statusCode:     http.StatusOK,
+			body:           "<h1>index</h1>",
+			expectedHeader: map[string]string{"Last-Modified": "Wed, 01 Jan 2020 00:00:00 GMT"},
+		},
+		{
+			title:          "Fingerprinted file",
+			path:           "/assets/app.3f2a9c1b.js",
+			statusCode:     http.StatusOK,
+			expectedHeader: map[string]string{"Cache-Control": "public, max-age=31536000, immutable"},
+		},
+		{
+			title:          "Precompressed brotli",
+			path:           "/assets/style.css",
+			header:         map[string]string{"Accept-Encoding": "gzip, br"},
+			statusCode:     http.StatusOK,
+			body:           "brotli",
+			expectedHeader: map[string]string{"Content-Encoding": "br", "Content-Type": "text/css; charset=utf-8", "Vary": "Accept-Encoding"},
+		},
+		{
+			title:          "Precompressed gzip",
+			path:           "/assets/style.css",
+			header:         map[string]string{"Accept-Encoding": "gzip, br;q=0"},
+			statusCode:     http.StatusOK,
+			body:           "gzip",
+			expectedHeader: map[string]string{"Content-Encoding": "gzip"},
+		},
+		{
+			title:          "Uncompressed",
+			path:           "/assets/style.css",
+			statusCode:     http.StatusOK,
+			body:           "body {}",
+			expectedHeader: map[string]string{"Content-Encoding": ""},
+		},
+		{
+			title:      "Directory listing off",
+			path:       "/assets/images/",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			title:      "Directory listing on",
+			path:       "/assets/images/",
+			opts:       FileServerOptions{DirectoryListing: true},
+			statusCode: http.StatusOK,
+			body:       "<a href=\"/assets/images/icons/\">icons/</a>\n<a href=\"/assets/images/logo.svg\">logo.svg</a>",
+		},
+		{
+			title:      "Unknown file",
+			path:       "/assets/user/42",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			title:      "Unknown file (SPA)",
+			path:       "/assets/user/42",
+			opts:       FileServerOptions{SPA: true},
+			statusCode: http.StatusOK,
+			body:       "<h1>index</h1>",
+		},
+		{
+			title:      "Unknown file with extension (SPA)",
+			path:       "/assets/missing.js",
+			opts:       FileServerOptions{SPA: true},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			title:      "Original case",
+			path:       "/assets/Logo.PNG",
+			statusCode: http.StatusOK,
+			body:       "png",
+		},
+		{
+			title:      "Other case",
+			path:       "/assets/logo.png",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			title:      "Escaping the root",
+			path:       "/assets/../../secret",
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			r := Classic()
+			r.SkipClean = true
+			r.ServeFilesWithOptions("/assets/*filepath", testFiles, test.opts)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost/", nil)
+			req.URL.Path = test.path
+			for k, v := range test.header {
+				req.Header.Set(k, v)
+			}
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Code != test.statusCode || !strings.Contains(res.Body.String(), test.body) {
+				t.Errorf("Unexpected response (Status code: %d, Body: %s)", res.Code, res.Body.String())
+			}
+
+			for k, v := range test.expectedHeader {
+				if res.Header().Get(k) != v {
+					t.Errorf("Unexpected header %s (Expected: %s, Actucal: %s)", k, v, res.Header().Get(k))
+				}
+			}
+		})
+	}
+}
+
+func TestServeFilesConditional(t *testing.T) {
+	r := Classic()
+	r.ServeFiles("/assets/*filepath", testFiles)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/assets/style.css", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	etag := res.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `"`) {
+		t.Fatalf("Unexpected ETag (%s)", etag)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "http://localhost/assets/style.css", nil)
+	req.Header.Set("If-None-Match", etag)
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusNotModified {
+		t.Errorf("Unexpected status code (%d)", res.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodHead, "http://localhost/assets/style.css", nil)
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK || res.Body.Len() != 0 {
+		t.Errorf("Unexpected response (Status code: %d, Body: %s)", res.Code, res.Body.String())
+	}
+}
+
+func TestServeFilesRank(t *testing.T) {
+	r := Classic()
+	r.ServeFiles("/*filepath", testFiles)
+	r.Get("/user/:number", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("user"))
+	})
+	r.SortRoutes()
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/user/42", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Body.String() != "user" {
+		t.Errorf("Unexpected body (%s)", res.Body.String())
+	}
+}
+
+func TestServeFilesFail(t *testing.T) {
+	r := Classic()
+	r.ServeFiles("/assets", testFiles)
+
+	// the GET and the HEAD route
+	if ok, errs := r.HasErrors(); !ok || len(errs) != 2 {
+		t.Errorf("Unexpected route errors (%v)", errs)
+	}
+}
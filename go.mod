@@ -0,0 +1,3 @@
+module github.com/donutloop/mux
+
+go 1.21
@@ -140,3 +140,18 @@ func containsRegex(path string) bool {
 func containsVars(path string) bool {
 	return strings.Contains(path, ":")
 }
+
+// containsWildcard returns true if the last segment of the path is a wildcard
+func containsWildcard(path string) bool {
+	return strings.HasPrefix(path[strings.LastIndex(path, "/")+1:], "*")
+}
+
+// splitWildcard splits the path into the prefix and the wildcard name
+func splitWildcard(path string) (string, string) {
+	index := strings.LastIndex(path, "/")
+	if index == -1 {
+		return "", path
+	}
+
+	return path[:index], path[index+1:]
+}
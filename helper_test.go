@@ -54,7 +54,7 @@ func TestConvertStringsToMapRegex(t *testing.T) {
 
 	m, _ := convertStringsToMapRegex(isEvenPairs, pairs...)
 	if value, ok := m["content-type"]; !ok || !value.compare("application/json") {
-		t.Errorf("Unexpected pair (%s)", value.(regexComparsion))
+		t.Errorf("Unexpected pair (%v)", value.(regexComparsion))
 	}
 }
 
@@ -0,0 +1,150 @@
+package mux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const systemdListenFDsStart = 3
+
+// ServeUnix is like Serve but it listens on the Unix domain socket at path.
+// A stale socket file is removed before and the socket is removed after
+// serving, the permissions of the socket are set to mode, e.g. 0660.
+func (r *Router) ServeUnix(ctx context.Context, path string, mode os.FileMode, opts ServeOptions) error {
+	if err := r.prepare(); err != nil {
+		return err
+	}
+
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return fmt.Errorf("mux: %s exists and is not a socket", path)
+		}
+
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	listener, err := listenUnix(path, mode)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(path)
+
+	return r.serveListeners(ctx, opts, listener)
+}
+
+// listenUnix listens on the Unix domain socket at path with the permissions of mode.
+// The socket is created in a private directory next to path and moved to path
+// after its mode is set, so it's never reachable with other permissions.
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".mux-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	tmp := filepath.Join(dir, "sock")
+	listener, err := net.Listen("unix", tmp)
+	if err != nil {
+		return nil, err
+	}
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	err = os.Chmod(tmp, mode)
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+// ServeSystemd is like Serve but it accepts the connections of all sockets
+// passed by the systemd socket activation, see SystemdListeners
+func (r *Router) ServeSystemd(ctx context.Context, opts ServeOptions) error {
+	if err := r.prepare(); err != nil {
+		return err
+	}
+
+	listeners, err := SystemdListeners()
+	if err != nil {
+		return err
+	}
+
+	if len(listeners) == 0 {
+		return errors.New("mux: no sockets passed by systemd (LISTEN_FDS)")
+	}
+
+	return r.serveListeners(ctx, opts, listeners...)
+}
+
+// SystemdListeners returns the listeners of the sockets passed by the
+// systemd socket activation (LISTEN_PID, LISTEN_FDS and LISTEN_FDNAMES).
+// It returns no listeners if the sockets aren't passed to this process.
+// The environment variables are unset, so child processes don't adopt the sockets.
+func SystemdListeners() ([]net.Listener, error) {
+	count, names, err := systemdListenFDs(os.Getenv("LISTEN_PID"), os.Getenv("LISTEN_FDS"), os.Getenv("LISTEN_FDNAMES"))
+	if err != nil || count == 0 {
+		return nil, err
+	}
+
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	listeners := make([]net.Listener, 0, count)
+	for i := 0; i < count; i++ {
+		file := os.NewFile(uintptr(systemdListenFDsStart+i), names[i])
+		listener, err := net.FileListener(file)
+		file.Close()
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return nil, fmt.Errorf("mux: socket %d (%s) passed by systemd: %v", systemdListenFDsStart+i, names[i], err)
+		}
+		listeners = append(listeners, listener)
+	}
+
+	return listeners, nil
+}
+
+// systemdListenFDs parses the environment variables of the socket activation.
+func systemdListenFDs(pid string, fds string, fdNames string) (int, []string, error) {
+	if pid == "" || fds == "" {
+		return 0, nil, nil
+	}
+
+	if pid != strconv.Itoa(os.Getpid()) {
+		return 0, nil, nil
+	}
+
+	count, err := strconv.Atoi(fds)
+	if err != nil || count < 0 {
+		return 0, nil, fmt.Errorf("mux: invalid LISTEN_FDS %q", fds)
+	}
+
+	names := make([]string, count)
+	if fdNames != "" {
+		copy(names, strings.Split(fdNames, ":"))
+	}
+
+	for k, name := range names {
+		if name == "" {
+			names[k] = "LISTEN_FD_" + strconv.Itoa(systemdListenFDsStart+k)
+		}
+	}
+
+	return count, names, nil
+}
@@ -0,0 +1,209 @@
+package mux
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestServeListener(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	r := Classic()
+	r.Get("/echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("echo"))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- r.ServeListener(ctx, listener, ServeOptions{})
+	}()
+
+	res, err := http.Get("http://" + listener.Addr().String() + "/echo")
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK || string(body) != "echo" {
+		t.Errorf("Unexpected response (Status code: %d, Body: %s)", res.StatusCode, body)
+	}
+
+	cancel()
+	if err := <-served; err != nil {
+		t.Errorf("Unexpected error (%s)", err.Error())
+	}
+}
+
+// failingListener fails to accept connections.
+type failingListener struct {
+	net.Listener
+}
+
+func (l failingListener) Accept() (net.Conn, error) {
+	return nil, errors.New("accept failed")
+}
+
+func TestServeListenersFail(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	failing, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- Classic().serveListeners(context.Background(), ServeOptions{}, listener, failingListener{failing})
+	}()
+
+	select {
+	case err := <-served:
+		if err == nil || err.Error() != "accept failed" {
+			t.Errorf("Unexpected error (%v)", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the server to stop after a listener failed")
+	}
+
+	if conn, err := net.Dial("tcp", listener.Addr().String()); err == nil {
+		conn.Close()
+		t.Error("Expected the other listener to be closed")
+	}
+}
+
+func TestServeUnix(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets are not supported")
+	}
+
+	dir, err := os.MkdirTemp("", "mux")
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mux.sock")
+
+	// stale socket of a previous run
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	r := Classic()
+	r.Get("/echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("echo"))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- r.ServeUnix(ctx, path, 0600, ServeOptions{})
+	}()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return net.Dial("unix", path)
+			},
+		},
+	}
+
+	var res *http.Response
+	for i := 0; i < 50; i++ {
+		if res, err = client.Get("http://unix/echo"); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	if string(body) != "echo" {
+		t.Errorf("Unexpected body (%s)", body)
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Unexpected socket file (%v, %v)", info, err)
+	}
+
+	cancel()
+	if err := <-served; err != nil {
+		t.Errorf("Unexpected error (%s)", err.Error())
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the socket file to be removed (%v)", err)
+	}
+}
+
+func TestServeUnixFail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(path, []byte("data"), 0600)
+
+	if err := Classic().ServeUnix(context.Background(), path, 0600, ServeOptions{}); err == nil {
+		t.Error("Expected a error")
+	}
+}
+
+func TestSystemdListenFDs(t *testing.T) {
+	pid := strconv.Itoa(os.Getpid())
+
+	tests := []struct {
+		title   string
+		pid     string
+		fds     string
+		fdNames string
+		count   int
+		names   []string
+		fail    bool
+	}{
+		{title: "Not activated"},
+		{title: "Other process", pid: "1", fds: "2"},
+		{title: "Named sockets", pid: pid, fds: "2", fdNames: "http:admin", count: 2, names: []string{"http", "admin"}},
+		{title: "Unnamed sockets", pid: pid, fds: "1", count: 1, names: []string{"LISTEN_FD_3"}},
+		{title: "Invalid count", pid: pid, fds: "x", fail: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			count, names, err := systemdListenFDs(test.pid, test.fds, test.fdNames)
+			if (err != nil) != test.fail {
+				t.Fatalf("Unexpected error (%v)", err)
+			}
+
+			if count != test.count || (count != 0 && !reflect.DeepEqual(names, test.names)) {
+				t.Errorf("Unexpected result (%d, %v)", count, names)
+			}
+		})
+	}
+}
+
+func TestServeSystemdFail(t *testing.T) {
+	os.Unsetenv("LISTEN_PID")
+	if err := Classic().ServeSystemd(context.Background(), ServeOptions{}); err == nil {
+		t.Error("Expected a error")
+	}
+}
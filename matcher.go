@@ -58,6 +58,31 @@ func (m headerRegexMatcher) Rank() int {
 	return rankAny
 }
 
+// queryMatcher matches the request against query values.
+type queryMatcher map[string]comparison
+
+func newQueryMatcher(pairs ...string) (queryMatcher, error) {
+	queries, err := convertStringsToMapString(isEvenPairs, pairs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryMatcher(queries), nil
+}
+
+func (m queryMatcher) Match(r *http.Request) bool {
+	queries, err := extractQueries(r)
+	if err != nil {
+		return false
+	}
+
+	return matchMap(m, queries, false)
+}
+
+func (m queryMatcher) Rank() int {
+	return rankAny
+}
+
 // MatcherFunc is the function signature used by custom Matchers.
 type MatcherFunc func(*http.Request) bool
 
@@ -84,7 +109,7 @@ func newSchemeMatcher(schemes ...string) schemeMatcher {
 }
 
 func (m schemeMatcher) Match(r *http.Request) bool {
-	if _, found := m[r.URL.Scheme]; found {
+	if _, found := m[GetScheme(r)]; found {
 		return true
 	}
 
@@ -111,25 +136,21 @@ type pathWithVarsMatcher struct {
 	regex *regexp.Regexp
 }
 
-func newPathWithVarsMatcher(path string) pathWithVarsMatcher {
+func newPathWithVarsMatcher(path string, paramTypes map[string]ParamType) pathWithVarsMatcher {
 
-Loop:
-	for {
-		switch {
-		case strings.Contains(path, ":number"):
-			path = strings.Replace(path, ":number", "([0-9]{1,})", -1)
-			continue
-		case strings.Contains(path, ":string"):
-			path = strings.Replace(path, ":string", "([a-zA-Z]{1,})", -1)
+	urlSeg := strings.Split(path, "/")
+	for k, v := range urlSeg {
+		if !strings.HasPrefix(v, ":") {
 			continue
-		default:
+		}
 
-			break Loop
+		if paramType, found := paramTypes[v[1:]]; found {
+			urlSeg[k] = "(" + paramType.Pattern + ")"
 		}
 	}
 
 	return pathWithVarsMatcher{
-		regex: regexp.MustCompile(`^` + path + `$`),
+		regex: regexp.MustCompile(`^` + strings.Join(urlSeg, "/") + `$`),
 	}
 }
 
@@ -141,6 +162,21 @@ func (m pathWithVarsMatcher) Match(r *http.Request) bool {
 	return m.regex.MatchString(r.URL.Path)
 }
 
+// pathWildcardMatcher matches the request against a URL path prefix.
+type pathWildcardMatcher string
+
+func (m pathWildcardMatcher) Match(r *http.Request) bool {
+	if r.URL.Path == string(m) {
+		return true
+	}
+
+	return strings.HasPrefix(r.URL.Path, string(m)+"/")
+}
+
+func (m pathWildcardMatcher) Rank() int {
+	return rankPath
+}
+
 //pathWithVarsMatcher matches the request against a URL path.
 type pathRegexMatcher struct {
 	regex *regexp.Regexp
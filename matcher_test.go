@@ -1,6 +1,7 @@
 package mux
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -46,6 +47,22 @@ func TestSchemeMatcher(t *testing.T) {
 	}
 }
 
+func TestSchemeMatcherServerRequest(t *testing.T) {
+	matcher := newSchemeMatcher("https")
+	request := &http.Request{
+		URL: new(url.URL),
+	}
+
+	if matcher.Match(request) {
+		t.Error("Scheme matched without TLS")
+	}
+
+	request.TLS = &tls.ConnectionState{}
+	if !matcher.Match(request) {
+		t.Error("Scheme not matched with TLS")
+	}
+}
+
 func BenchmarkSchemeMatcher(b *testing.B) {
 	matcher := newSchemeMatcher("https", "http", "HTTP", "HTTPS")
 	request := &http.Request{
@@ -95,7 +112,7 @@ func TestPathMatchers(t *testing.T) {
 			pathToMatch: "/user/:number",
 			pathRaw:     "/user/1",
 			buildMatcher: func(path string) Matcher {
-				return newPathWithVarsMatcher(path)
+				return newPathWithVarsMatcher(path, defaultParamTypes)
 			},
 		},
 		{
@@ -103,7 +120,7 @@ func TestPathMatchers(t *testing.T) {
 			pathToMatch: "/user/:number/comment/:number",
 			pathRaw:     "/user/1/comment/99",
 			buildMatcher: func(path string) Matcher {
-				return newPathWithVarsMatcher(path)
+				return newPathWithVarsMatcher(path, defaultParamTypes)
 			},
 		},
 		{
@@ -111,7 +128,7 @@ func TestPathMatchers(t *testing.T) {
 			pathToMatch: "/article/:string",
 			pathRaw:     "/article/golang",
 			buildMatcher: func(path string) Matcher {
-				return newPathWithVarsMatcher(path)
+				return newPathWithVarsMatcher(path, defaultParamTypes)
 			},
 		},
 		{
@@ -119,7 +136,7 @@ func TestPathMatchers(t *testing.T) {
 			pathToMatch: "/article/:string/comment/:number/subcomment/:number",
 			pathRaw:     "/article/golang/comment/4/subcomment/5",
 			buildMatcher: func(path string) Matcher {
-				return newPathWithVarsMatcher(path)
+				return newPathWithVarsMatcher(path, defaultParamTypes)
 			},
 		},
 		{
@@ -127,7 +144,7 @@ func TestPathMatchers(t *testing.T) {
 			pathToMatch: "/:number/:number/:number/:number/:number/:number/:number/:number/:number/:number",
 			pathRaw:     "/1/1/1/1/1/1/1/1/1/1",
 			buildMatcher: func(path string) Matcher {
-				return newPathWithVarsMatcher(path)
+				return newPathWithVarsMatcher(path, defaultParamTypes)
 			},
 		},
 		{
@@ -135,7 +152,7 @@ func TestPathMatchers(t *testing.T) {
 			pathToMatch: "/:string/:number/:string/:number/:string/:number/:string/:number/:string/:number",
 			pathRaw:     "/dummy/1/dummy/1/dummy/1/dummy/1/dummy/1",
 			buildMatcher: func(path string) Matcher {
-				return newPathWithVarsMatcher(path)
+				return newPathWithVarsMatcher(path, defaultParamTypes)
 			},
 		},
 		{
@@ -193,7 +210,7 @@ func BenchmarkPathMatchers(b *testing.B) {
 			pathToMatch: "/:string/:number/:string/:number/:string/:number/:string/:number/:string/:number",
 			pathRaw:     "/user/1",
 			buildMatcher: func(path string) Matcher {
-				return newPathWithVarsMatcher(path)
+				return newPathWithVarsMatcher(path, defaultParamTypes)
 			},
 		},
 		{
@@ -451,7 +468,7 @@ func BenchmarkNewPathWithVarsMatcher(b *testing.B) {
 	for _, test := range tests {
 		b.Run(test.title, func(b *testing.B) {
 			for n := 0; n < b.N; n++ {
-				newPathWithVarsMatcher(test.path)
+				newPathWithVarsMatcher(test.path, defaultParamTypes)
 			}
 		})
 	}
@@ -0,0 +1,164 @@
+package mux
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultMetricsBuckets are the default latency buckets in seconds.
+var DefaultMetricsBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Metrics collects request counts, the in-flight requests and the latencies
+// of the router. The requests are labelled by method, route template and status,
+// e.g. /user/:number instead of /user/42, so the count of series stays low.
+// Requests without matching route have an empty route label.
+//
+//     r := mux.Classic()
+//     metrics := r.EnableMetrics()
+//     r.Get("/metrics", metrics.ServeHTTP)
+//
+// Metrics is a http.Handler which writes the Prometheus text format.
+type Metrics struct {
+	buckets  []float64
+	inFlight int64
+	mu       sync.Mutex
+	series   map[metricsKey]*metricsSeries
+}
+
+type metricsKey struct {
+	method string
+	route  string
+	status int
+}
+
+type metricsSeries struct {
+	count   uint64
+	sum     float64
+	buckets []uint64
+}
+
+// EnableMetrics enables the metrics of the router and returns the collector.
+// The buckets are the upper bounds of the latency histogram in seconds,
+// DefaultMetricsBuckets are used if none are given.
+// Calling it again returns the same collector.
+func (r *Router) EnableMetrics(buckets ...float64) *Metrics {
+	if r.metrics == nil {
+		if len(buckets) == 0 {
+			buckets = DefaultMetricsBuckets
+		}
+
+		buckets = append([]float64(nil), buckets...)
+		sort.Float64s(buckets)
+
+		r.metrics = &Metrics{
+			buckets: buckets,
+			series:  map[metricsKey]*metricsSeries{},
+		}
+	}
+
+	return r.metrics
+}
+
+// observe records a finished request.
+func (m *Metrics) observe(req *http.Request, status int, duration time.Duration) {
+	key := metricsKey{method: req.Method, status: status}
+	if route := CurrentRoute(req); route != nil {
+		key.route = route.GetPath()
+	}
+
+	seconds := duration.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	series, found := m.series[key]
+	if !found {
+		series = &metricsSeries{buckets: make([]uint64, len(m.buckets))}
+		m.series[key] = series
+	}
+
+	series.count++
+	series.sum += seconds
+	for k, bound := range m.buckets {
+		if seconds <= bound {
+			series.buckets[k]++
+		}
+	}
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	keys := make([]metricsKey, 0, len(m.series))
+	series := make(map[metricsKey]metricsSeries, len(m.series))
+	for k, v := range m.series {
+		keys = append(keys, k)
+		series[k] = metricsSeries{count: v.count, sum: v.sum, buckets: append([]uint64(nil), v.buckets...)}
+	}
+	m.mu.Unlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].route != keys[j].route {
+			return keys[i].route < keys[j].route
+		}
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].status < keys[j].status
+	})
+
+	b := &strings.Builder{}
+
+	b.WriteString("# HELP mux_requests_total Total number of requests by method, route and status.\n")
+	b.WriteString("# TYPE mux_requests_total counter\n")
+	for _, key := range keys {
+		fmt.Fprintf(b, "mux_requests_total{%s} %d\n", key.labels(), series[key].count)
+	}
+
+	b.WriteString("# HELP mux_requests_in_flight Number of requests currently served.\n")
+	b.WriteString("# TYPE mux_requests_in_flight gauge\n")
+	fmt.Fprintf(b, "mux_requests_in_flight %d\n", atomic.LoadInt64(&m.inFlight))
+
+	b.WriteString("# HELP mux_request_duration_seconds Latency of requests by method, route and status.\n")
+	b.WriteString("# TYPE mux_request_duration_seconds histogram\n")
+	for _, key := range keys {
+		s := series[key]
+		labels := key.labels()
+		for k, bound := range m.buckets {
+			fmt.Fprintf(b, "mux_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", labels, formatMetricsFloat(bound), s.buckets[k])
+		}
+		fmt.Fprintf(b, "mux_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, s.count)
+		fmt.Fprintf(b, "mux_request_duration_seconds_sum{%s} %s\n", labels, formatMetricsFloat(s.sum))
+		fmt.Fprintf(b, "mux_request_duration_seconds_count{%s} %d\n", labels, s.count)
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (k metricsKey) labels() string {
+	return fmt.Sprintf("method=\"%s\",route=\"%s\",status=\"%d\"", escapeLabelValue(k.method), escapeLabelValue(k.route), k.status)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+func formatMetricsFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,81 @@
+package mux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	r := Classic()
+	metrics := r.EnableMetrics(0.1, 1)
+
+	if r.EnableMetrics() != metrics {
+		t.Error("Expected the same collector")
+	}
+
+	r.Get("/user/:number", func(w http.ResponseWriter, r *http.Request) {})
+	r.Get("/metrics", metrics.ServeHTTP)
+
+	for _, path := range []string{"/user/1", "/user/2", "/article/1"} {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost"+path, nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/metrics", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if !strings.HasPrefix(res.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Errorf("Unexpected content type (%s)", res.Header().Get("Content-Type"))
+	}
+
+	expected := []string{
+		"# TYPE mux_requests_total counter",
+		`mux_requests_total{method="GET",route="/user/:number",status="200"} 2`,
+		`mux_requests_total{method="GET",route="",status="404"} 1`,
+		"mux_requests_in_flight 1",
+		"# TYPE mux_request_duration_seconds histogram",
+		`mux_request_duration_seconds_bucket{method="GET",route="/user/:number",status="200",le="0.1"} 2`,
+		`mux_request_duration_seconds_bucket{method="GET",route="/user/:number",status="200",le="1"} 2`,
+		`mux_request_duration_seconds_bucket{method="GET",route="/user/:number",status="200",le="+Inf"} 2`,
+		`mux_request_duration_seconds_count{method="GET",route="/user/:number",status="200"} 2`,
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(res.Body.String(), line+"\n") {
+			t.Errorf("Missing line (%s) in (%s)", line, res.Body.String())
+		}
+	}
+}
+
+func TestMetricsObserve(t *testing.T) {
+	r := Classic()
+	metrics := r.EnableMetrics(0.5, 1)
+
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost/", nil)
+	metrics.observe(req, http.StatusCreated, 750*time.Millisecond)
+
+	b := &strings.Builder{}
+	metrics.WriteTo(b)
+
+	expected := []string{
+		`mux_request_duration_seconds_bucket{method="POST",route="",status="201",le="0.5"} 0`,
+		`mux_request_duration_seconds_bucket{method="POST",route="",status="201",le="1"} 1`,
+		`mux_request_duration_seconds_sum{method="POST",route="",status="201"} 0.75`,
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("Missing line (%s) in (%s)", line, b.String())
+		}
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	if value := escapeLabelValue("a\"b\\c\nd"); value != `a\"b\\c\nd` {
+		t.Errorf("Unexpected value (%s)", value)
+	}
+}
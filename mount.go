@@ -0,0 +1,98 @@
+package mux
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// mountWildcard is the name of the wildcard var of mounted handlers.
+const mountWildcard = "*mountpath"
+
+// Mount registers the handler for all standard methods under the prefix,
+// e.g. a http.ServeMux or a third-party admin UI. The prefix is stripped from
+// the path with the case of the client before the handler is called, the
+// original URL is available with OriginalURL.
+//
+//     r.Mount("/legacy", legacyMux)
+//
+// Handlers which expect the full path, e.g. pprof, are registered with a
+// wildcard route instead:
+//
+//     r.Handle(http.MethodGet, "/debug/pprof/*name", http.HandlerFunc(pprof.Index))
+//
+// Mounts rank below all other routes, so more specific routes of the router
+// are matched first. It returns the registered routes in order of the methods.
+func (r *Router) Mount(prefix string, handler http.Handler) []RouteInterface {
+	prefix = strings.TrimSuffix(prefix, "/")
+	path := prefix + "/" + mountWildcard
+	stripped := stripPrefix(prefix, handler)
+
+	routes := make([]RouteInterface, 0, len(methods))
+	for _, method := range sortedMethods() {
+		routes = append(routes, r.Handle(method, path, stripped))
+	}
+
+	return routes
+}
+
+// sortedMethods returns the standard methods in alphabetical order.
+func sortedMethods() []string {
+	names := make([]string, 0, len(methods))
+	for method := range methods {
+		names = append(names, method)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// stripPrefix removes the prefix from the path and the escaped prefix from
+// the raw path of the request. The path keeps the case of the client unless
+// it was rewritten. The raw path is dropped if it doesn't match the stripped
+// path, e.g. the client escaped the prefix differently.
+func stripPrefix(prefix string, handler http.Handler) http.Handler {
+	escapedPrefix := (&url.URL{Path: prefix}).EscapedPath()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		source := req.URL
+		if original := OriginalURL(req); strings.EqualFold(original.Path, req.URL.Path) {
+			source = original
+		}
+		req = AddOriginalURL(req, req.URL)
+
+		u := new(url.URL)
+		*u = *req.URL
+		u.Path = ensureLeadingSlash(trimPrefixFold(source.Path, prefix))
+		u.RawPath = ""
+		if source.RawPath != "" {
+			u.RawPath = ensureLeadingSlash(trimPrefixFold(source.RawPath, escapedPrefix))
+			if path, err := url.PathUnescape(u.RawPath); err != nil || path != u.Path {
+				u.RawPath = ""
+			}
+		}
+
+		stripped := new(http.Request)
+		*stripped = *req
+		stripped.URL = u
+		handler.ServeHTTP(w, stripped)
+	})
+}
+
+// trimPrefixFold removes the prefix from s, the case is ignored.
+func trimPrefixFold(s string, prefix string) string {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):]
+	}
+
+	return s
+}
+
+func ensureLeadingSlash(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		return "/" + path
+	}
+
+	return path
+}
@@ -0,0 +1,120 @@
+package mux
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/pprof"
+	"strings"
+	"testing"
+)
+
+func TestMount(t *testing.T) {
+
+	tests := []struct {
+		title    string
+		method   string
+		path     string
+		expected string
+	}{
+		{title: "Mounted path", method: http.MethodGet, path: "/legacy/users", expected: "legacy /users /legacy/users"},
+		{title: "Mounted root", method: http.MethodPost, path: "/legacy", expected: "legacy / /legacy"},
+		{title: "Mounted root with slash", method: http.MethodDelete, path: "/legacy/", expected: "legacy / /legacy/"},
+		{title: "Escaped path", method: http.MethodGet, path: "/legacy/a%2fb", expected: "legacy /a/b /legacy/a%2fb"},
+		{title: "Original case", method: http.MethodGet, path: "/Legacy/users", expected: "legacy /users /Legacy/users"},
+		{title: "Original case of the rest", method: http.MethodGet, path: "/legacy/Users/ABC", expected: "legacy /Users/ABC /legacy/Users/ABC"},
+		{title: "Specific route", method: http.MethodGet, path: "/legacy/health", expected: "health"},
+		{title: "Other prefix", method: http.MethodGet, path: "/legacyx", expected: "404 page not found\n"},
+	}
+
+	legacy := http.NewServeMux()
+	legacy.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "legacy %s %s", r.URL.Path, OriginalURL(r).EscapedPath())
+	})
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			r := Classic()
+			r.Get("/legacy/health", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("health"))
+			})
+
+			routes := r.Mount("/legacy/", legacy)
+			if len(routes) != len(methods) {
+				t.Fatalf("Unexpected count of routes (%d)", len(routes))
+			}
+			r.SortRoutes()
+
+			req := httptest.NewRequest(test.method, "http://localhost"+test.path, nil)
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Body.String() != test.expected {
+				t.Errorf("Unexpected body (Expected: %s, Actucal: %s)", test.expected, res.Body.String())
+			}
+		})
+	}
+}
+
+func TestMountEscapedPrefix(t *testing.T) {
+
+	tests := []struct {
+		title    string
+		path     string
+		expected string
+	}{
+		{title: "Escaped prefix", path: "/my%20files/a%2fb", expected: "/a/b /a%2fb"},
+		{title: "Unescaped rest", path: "/my%20files/a/b", expected: "/a/b /a/b"},
+		{title: "Differently escaped prefix", path: "/my%20fil%65s/a%2fb", expected: "/a/b /a/b"},
+	}
+
+	r := Classic()
+	r.Mount("/my files", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s", r.URL.Path, r.URL.EscapedPath())
+	}))
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost"+test.path, nil)
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Body.String() != test.expected {
+				t.Errorf("Unexpected body (Expected: %s, Actucal: %s)", test.expected, res.Body.String())
+			}
+		})
+	}
+}
+
+func TestPprofWildcard(t *testing.T) {
+	r := Classic()
+	r.Handle(http.MethodGet, "/debug/pprof/*name", http.HandlerFunc(pprof.Index))
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/debug/pprof/heap?debug=1", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK || !strings.HasPrefix(res.Body.String(), "heap profile") {
+		t.Errorf("Unexpected response (Status code: %d, Body: %.100s)", res.Code, res.Body.String())
+	}
+}
+
+func TestOriginalURLOnlyIfChanged(t *testing.T) {
+	r := Classic()
+
+	var stored []bool
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		stored = append(stored, contextGet(req, originalURLKey) != nil)
+	}
+	r.Get("/user", handler)
+	r.Get("/new", handler)
+	r.Rewrite("/old", "/new")
+
+	for _, path := range []string{"/user", "/User", "/old"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://localhost"+path, nil))
+	}
+
+	if len(stored) != 3 || stored[0] || !stored[1] || !stored[2] {
+		t.Errorf("Unexpected stored original URLs (%v)", stored)
+	}
+}
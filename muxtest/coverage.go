@@ -0,0 +1,80 @@
+package muxtest
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/donutloop/mux"
+)
+
+// Coverage tracks which routes of a router were exercised by the tests.
+//
+// Routes are counted by the assertions of the coverage and by requests
+// served by the router, e.g. httptest.NewServer(coverage).
+// The served requests are recorded by the router, see mux.Router.EnableCoverage
+type Coverage struct {
+	router   *mux.Router
+	recorder *mux.CoverageRecorder
+	mu       sync.Mutex
+	asserted map[mux.RouteInterface]int
+}
+
+// Track starts tracking the route coverage of the router.
+// Coverages of the same router share the counts of the served requests.
+func Track(router *mux.Router) *Coverage {
+	return &Coverage{
+		router:   router,
+		recorder: router.EnableCoverage(),
+		asserted: map[mux.RouteInterface]int{},
+	}
+}
+
+// AssertRoute is like AssertRoute of the package, the matched route is counted.
+func (c *Coverage) AssertRoute(t testing.TB, method, path string, expectations ...Expectation) mux.RouteInterface {
+	t.Helper()
+
+	route := AssertRoute(t, c.router, method, path, expectations...)
+	if route != nil {
+		c.mu.Lock()
+		c.asserted[route]++
+		c.mu.Unlock()
+	}
+
+	return route
+}
+
+// ServeHTTP dispatches the request to the router.
+func (c *Coverage) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	c.router.ServeHTTP(w, req)
+}
+
+// Hits returns how often the route was exercised.
+func (c *Coverage) Hits(route mux.RouteInterface) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.asserted[route] + c.recorder.Hits(route)
+}
+
+// Unexercised returns all registered routes which were never exercised.
+func (c *Coverage) Unexercised() []mux.RouteInterface {
+	unexercised := make([]mux.RouteInterface, 0)
+	c.router.Walk(func(method string, route mux.RouteInterface) error {
+		if c.Hits(route) == 0 {
+			unexercised = append(unexercised, route)
+		}
+		return nil
+	})
+
+	return unexercised
+}
+
+// Report reports an error for every route which was never exercised.
+func (c *Coverage) Report(t testing.TB) {
+	t.Helper()
+
+	for _, route := range c.Unexercised() {
+		t.Errorf("route never exercised: %s %s", route.GetMethodName(), route.GetPath())
+	}
+}
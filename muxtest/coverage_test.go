@@ -0,0 +1,54 @@
+package muxtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCoverage(t *testing.T) {
+	router := newTestRouter()
+	coverage := Track(router)
+
+	coverage.AssertRoute(t, http.MethodGet, "/user/1")
+	AssertRoute(t, router, http.MethodGet, "/user/2")
+	AssertNotFound(t, router, http.MethodGet, "/unknown")
+
+	server := httptest.NewServer(coverage)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodDelete, server.URL+"/user/1", nil)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+	res.Body.Close()
+
+	req, _ = http.NewRequest(http.MethodGet, server.URL+"/user/2", nil)
+	res, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+	res.Body.Close()
+
+	route := router.Match(httptest.NewRequest(http.MethodGet, "/user/3", nil))
+	if hits := coverage.Hits(route); hits != 2 {
+		t.Errorf("Unexpected hits (%d)", hits)
+	}
+
+	unexercised := coverage.Unexercised()
+	if len(unexercised) != 1 || unexercised[0].GetPath() != "/health" {
+		t.Fatalf("Unexpected unexercised routes (%v)", unexercised)
+	}
+
+	if report := router.EnableCoverage().Report(); report.NotFound != 0 {
+		t.Errorf("Unexpected not found count (%d)", report.NotFound)
+	}
+
+	ft := &fakeT{TB: t}
+	coverage.Report(ft)
+	if len(ft.errors) != 1 || !strings.Contains(ft.errors[0], "GET /health") {
+		t.Errorf("Unexpected report (%v)", ft.errors)
+	}
+}
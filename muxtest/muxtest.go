@@ -0,0 +1,146 @@
+// Package muxtest provides helpers for table driven route tests.
+//
+//     func TestRoutes(t *testing.T) {
+//         router := newRouter()
+//         coverage := muxtest.Track(router)
+//
+//         coverage.AssertRoute(t, "GET", "/user/42", muxtest.Named("user.show"), muxtest.Vars{":number": "42"})
+//         muxtest.AssertNotFound(t, router, "GET", "/unknown")
+//         muxtest.AssertMethodNotAllowed(t, router, "DELETE", "/user/42")
+//
+//         coverage.Report(t)
+//     }
+package muxtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/donutloop/mux"
+)
+
+// Expectation checks the route which matched the request.
+type Expectation interface {
+	check(t testing.TB, route mux.RouteInterface, req *http.Request)
+}
+
+type expectationFunc func(t testing.TB, route mux.RouteInterface, req *http.Request)
+
+func (f expectationFunc) check(t testing.TB, route mux.RouteInterface, req *http.Request) {
+	f(t, route, req)
+}
+
+// Named expects that the matched route has the given name.
+func Named(name string) Expectation {
+	return expectationFunc(func(t testing.TB, route mux.RouteInterface, req *http.Request) {
+		t.Helper()
+
+		named, ok := route.(interface{ GetName() string })
+		if !ok {
+			t.Errorf("%s %s: route has no name, expected %q", req.Method, req.URL.Path, name)
+			return
+		}
+
+		if named.GetName() != name {
+			t.Errorf("%s %s: unexpected route name (Expected: %q, Actucal: %q)", req.Method, req.URL.Path, name, named.GetName())
+		}
+	})
+}
+
+// Path expects that the matched route was registered with the given path.
+func Path(path string) Expectation {
+	return expectationFunc(func(t testing.TB, route mux.RouteInterface, req *http.Request) {
+		t.Helper()
+
+		if route.GetPath() != path {
+			t.Errorf("%s %s: unexpected route path (Expected: %q, Actucal: %q)", req.Method, req.URL.Path, path, route.GetPath())
+		}
+	})
+}
+
+// Vars expects that the matched route extracts exactly the given vars.
+type Vars map[string]string
+
+func (v Vars) check(t testing.TB, route mux.RouteInterface, req *http.Request) {
+	t.Helper()
+
+	vars := map[string]string{}
+	if route.HasVars() {
+		vars = route.ExtractVars(req).GetAll()
+	}
+
+	if !reflect.DeepEqual(map[string]string(v), vars) {
+		t.Errorf("%s %s: unexpected vars (Expected: %v, Actucal: %v)", req.Method, req.URL.Path, map[string]string(v), vars)
+	}
+}
+
+// AssertRoute asserts that a route matches the request like in ServeHTTP
+// (see mux.Router.Resolve) and that all expectations are met.
+// It returns the matched route.
+func AssertRoute(t testing.TB, router *mux.Router, method, path string, expectations ...Expectation) mux.RouteInterface {
+	t.Helper()
+
+	route, req := router.Resolve(httptest.NewRequest(method, path, nil))
+	if route == nil {
+		t.Errorf("%s %s: no route matched", method, path)
+		return nil
+	}
+
+	for _, expectation := range expectations {
+		expectation.check(t, route, req)
+	}
+
+	return route
+}
+
+// AssertNotFound asserts that no route matches the path with any method.
+func AssertNotFound(t testing.TB, router *mux.Router, method, path string) {
+	t.Helper()
+
+	if route := router.Match(httptest.NewRequest(method, path, nil)); route != nil {
+		t.Errorf("%s %s: unexpected matched route (%s %s)", method, path, route.GetMethodName(), route.GetPath())
+		return
+	}
+
+	if allowed := allowedMethods(router, path); len(allowed) != 0 {
+		t.Errorf("%s %s: unexpected matched route for methods %v", method, path, allowed)
+	}
+}
+
+// AssertMethodNotAllowed asserts that no route matches the path with the
+// method, but at least one route matches the path with another method.
+func AssertMethodNotAllowed(t testing.TB, router *mux.Router, method, path string) {
+	t.Helper()
+
+	if route := router.Match(httptest.NewRequest(method, path, nil)); route != nil {
+		t.Errorf("%s %s: unexpected matched route (%s %s)", method, path, route.GetMethodName(), route.GetPath())
+		return
+	}
+
+	if allowed := allowedMethods(router, path); len(allowed) == 0 {
+		t.Errorf("%s %s: no route matched with any method", method, path)
+	}
+}
+
+// allowedMethods returns the methods of the registered routes which match the path.
+func allowedMethods(router *mux.Router, path string) []string {
+	allowed := make([]string, 0)
+
+	var last string
+	router.Walk(func(method string, route mux.RouteInterface) error {
+		if method == last {
+			return nil
+		}
+		last = method
+
+		if router.Match(httptest.NewRequest(method, path, nil)) != nil {
+			allowed = append(allowed, method)
+		}
+
+		return nil
+	})
+
+	return allowed
+}
@@ -0,0 +1,98 @@
+package muxtest
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/donutloop/mux"
+)
+
+// fakeT records the errors of failed assertions.
+type fakeT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func newTestRouter() *mux.Router {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+
+	router := mux.Classic()
+	router.Get("/user/:number", handler).(*mux.Route).Name("user.show")
+	router.Delete("/user/:number", handler)
+	router.Get("/health", handler)
+
+	return router
+}
+
+func TestAssertRoute(t *testing.T) {
+	router := newTestRouter()
+
+	AssertRoute(t, router, http.MethodGet, "/user/42", Named("user.show"), Path("/user/:number"), Vars{":number": "42"})
+	AssertRoute(t, router, http.MethodGet, "/health", Vars{})
+	AssertNotFound(t, router, http.MethodGet, "/unknown")
+	AssertMethodNotAllowed(t, router, http.MethodPost, "/user/42")
+}
+
+func TestAssertRouteFail(t *testing.T) {
+	router := newTestRouter()
+
+	tests := []struct {
+		title  string
+		assert func(t testing.TB)
+	}{
+		{
+			title: "No route",
+			assert: func(t testing.TB) {
+				AssertRoute(t, router, http.MethodGet, "/unknown")
+			},
+		},
+		{
+			title: "Bad name",
+			assert: func(t testing.TB) {
+				AssertRoute(t, router, http.MethodGet, "/user/42", Named("user.delete"))
+			},
+		},
+		{
+			title: "Bad vars",
+			assert: func(t testing.TB) {
+				AssertRoute(t, router, http.MethodGet, "/user/42", Vars{":number": "43"})
+			},
+		},
+		{
+			title: "Found",
+			assert: func(t testing.TB) {
+				AssertNotFound(t, router, http.MethodGet, "/health")
+			},
+		},
+		{
+			title: "Other method",
+			assert: func(t testing.TB) {
+				AssertNotFound(t, router, http.MethodPost, "/health")
+			},
+		},
+		{
+			title: "No method",
+			assert: func(t testing.TB) {
+				AssertMethodNotAllowed(t, router, http.MethodPost, "/unknown")
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			ft := &fakeT{TB: t}
+			test.assert(ft)
+
+			if len(ft.errors) != 1 {
+				t.Errorf("Unexpected errors (%v)", ft.errors)
+			}
+		})
+	}
+}
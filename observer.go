@@ -0,0 +1,75 @@
+package mux
+
+import (
+	"net/http"
+	"time"
+)
+
+// Observer gets notified about the routing events of the router,
+// e.g. to adapt a tracing or auditing system. See Router.Observer
+//
+// The callbacks are called synchronously while the request is served.
+type Observer interface {
+	// OnMatch is called if a route matches, before its handler is called.
+	OnMatch(req *http.Request, route RouteInterface)
+	// OnNotFound is called if no route matches.
+	OnNotFound(req *http.Request)
+	// OnRedirect is called if the router redirects, e.g. to the cleaned path.
+	OnRedirect(req *http.Request, location string)
+	// OnPanic is called if a panic of a handler is recovered, see RecoverPanics.
+	OnPanic(req *http.Request, recovered interface{}, stack []byte)
+	// OnComplete is called after the request is served, the route is nil if no route matches.
+	OnComplete(req *http.Request, route RouteInterface, status int, duration time.Duration)
+}
+
+// NopObserver implements all callbacks of Observer without doing anything,
+// it can be embedded to implement only the needed callbacks.
+//
+//     type auditObserver struct {
+//         mux.NopObserver
+//     }
+//
+//     func (o auditObserver) OnMatch(req *http.Request, route mux.RouteInterface) {
+//         ...
+//     }
+type NopObserver struct{}
+
+func (NopObserver) OnMatch(req *http.Request, route RouteInterface)                {}
+func (NopObserver) OnNotFound(req *http.Request)                                   {}
+func (NopObserver) OnRedirect(req *http.Request, location string)                  {}
+func (NopObserver) OnPanic(req *http.Request, recovered interface{}, stack []byte) {}
+func (NopObserver) OnComplete(req *http.Request, route RouteInterface, status int, duration time.Duration) {
+}
+
+// Observers notifies all observers in order.
+type Observers []Observer
+
+func (o Observers) OnMatch(req *http.Request, route RouteInterface) {
+	for _, observer := range o {
+		observer.OnMatch(req, route)
+	}
+}
+
+func (o Observers) OnNotFound(req *http.Request) {
+	for _, observer := range o {
+		observer.OnNotFound(req)
+	}
+}
+
+func (o Observers) OnRedirect(req *http.Request, location string) {
+	for _, observer := range o {
+		observer.OnRedirect(req, location)
+	}
+}
+
+func (o Observers) OnPanic(req *http.Request, recovered interface{}, stack []byte) {
+	for _, observer := range o {
+		observer.OnPanic(req, recovered, stack)
+	}
+}
+
+func (o Observers) OnComplete(req *http.Request, route RouteInterface, status int, duration time.Duration) {
+	for _, observer := range o {
+		observer.OnComplete(req, route, status, duration)
+	}
+}
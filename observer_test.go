@@ -0,0 +1,92 @@
+package mux
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testObserver struct {
+	events []string
+}
+
+func (o *testObserver) OnMatch(req *http.Request, route RouteInterface) {
+	o.events = append(o.events, fmt.Sprintf("match %s %s", route.GetPath(), GetVars(req).Get(":number")))
+}
+
+func (o *testObserver) OnNotFound(req *http.Request) {
+	o.events = append(o.events, "notfound "+req.URL.Path)
+}
+
+func (o *testObserver) OnRedirect(req *http.Request, location string) {
+	o.events = append(o.events, "redirect "+location)
+}
+
+func (o *testObserver) OnPanic(req *http.Request, recovered interface{}, stack []byte) {
+	o.events = append(o.events, fmt.Sprintf("panic %v", recovered))
+}
+
+func (o *testObserver) OnComplete(req *http.Request, route RouteInterface, status int, duration time.Duration) {
+	path := ""
+	if route != nil {
+		path = route.GetPath()
+	}
+	o.events = append(o.events, fmt.Sprintf("complete %s %d", path, status))
+}
+
+func TestObserver(t *testing.T) {
+
+	tests := []struct {
+		title  string
+		path   string
+		events []string
+	}{
+		{
+			title:  "Match",
+			path:   "/user/42",
+			events: []string{"match /user/:number 42", "complete /user/:number 202"},
+		},
+		{
+			title:  "Not found",
+			path:   "/article",
+			events: []string{"notfound /article", "complete  404"},
+		},
+		{
+			title:  "Redirect",
+			path:   "/user//42",
+			events: []string{"redirect /user/42", "complete  301"},
+		},
+		{
+			title:  "Panic",
+			path:   "/panic",
+			events: []string{"match /panic ", "panic boom", "complete /panic 500"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			observer := &testObserver{}
+			r := Classic()
+			r.RecoverPanics = true
+			r.PanicHandler = func(w http.ResponseWriter, req *http.Request, recovered interface{}, stack []byte) {}
+			r.Observer = Observers{NopObserver{}, observer}
+
+			r.Get("/user/:number", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			})
+			r.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+				panic("boom")
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost"+test.path, nil)
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !reflect.DeepEqual(observer.events, test.events) {
+				t.Errorf("Unexpected events (Expected: %v, Actucal: %v)", test.events, observer.events)
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package mux
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OpenAPIInfo describes the API in the generated OpenAPI document.
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// RouteDoc holds the optional documentation of a route.
+//
+// Request and Response are sample values of the body types, e.g. User{},
+// their JSON schema is derived from the type and its json tags.
+type RouteDoc struct {
+	Summary     string
+	Description string
+	Tags        []string
+	Request     interface{}
+	Response    interface{}
+}
+
+type openAPIDocument struct {
+	OpenAPI string                                  `json:"openapi"`
+	Info    OpenAPIInfo                             `json:"info"`
+	Paths   map[string]map[string]*openAPIOperation `json:"paths"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []*openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+	Schemes     []string                    `json:"x-schemes,omitempty"`
+}
+
+type openAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required"`
+	Schema   *openAPISchema `json:"schema,omitempty"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema,omitempty"`
+}
+
+type openAPISchema struct {
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Pattern              string                    `json:"pattern,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+}
+
+const mediaTypeJSON = "application/json"
+
+// OpenAPI returns an OpenAPI 3.0 JSON document of all registered routes.
+//
+// Path vars are converted into the {param} form:
+//
+//     /user/:number/comment/:number  -> /user/{number}/comment/{number1}
+//     /article/#([a-z]{1,})          -> /article/{var}
+//
+// Header and query matchers become parameters, scheme matchers are listed in
+// the x-schemes extension. Routes with errors are skipped.
+func (r *Router) OpenAPI(info OpenAPIInfo) ([]byte, error) {
+	doc := openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   map[string]map[string]*openAPIOperation{},
+	}
+
+	for method, routesForMethod := range r.routes {
+		method = strings.ToLower(method)
+		for _, route := range routesForMethod {
+			if route.HasError() {
+				continue
+			}
+
+			path, parameters := openAPIPath(route.GetPath(), r.getParamTypes())
+			if _, found := doc.Paths[path]; !found {
+				doc.Paths[path] = map[string]*openAPIOperation{}
+			}
+
+			if _, found := doc.Paths[path][method]; found {
+				continue
+			}
+
+			doc.Paths[path][method] = newOpenAPIOperation(route, parameters)
+		}
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func newOpenAPIOperation(route RouteInterface, parameters []*openAPIParameter) *openAPIOperation {
+	operation := &openAPIOperation{
+		Parameters: parameters,
+		Responses: map[string]*openAPIResponse{
+			"200": {Description: http.StatusText(http.StatusOK)},
+		},
+	}
+
+	if named, ok := route.(interface{ GetName() string }); ok {
+		operation.OperationID = named.GetName()
+	}
+
+	for _, m := range route.GetMatchers() {
+		switch matcher := m.(type) {
+		case headerMatcher:
+			operation.Parameters = append(operation.Parameters, openAPIMatcherParameters("header", matcher, true)...)
+		case headerRegexMatcher:
+			operation.Parameters = append(operation.Parameters, openAPIMatcherParameters("header", matcher, true)...)
+		case queryMatcher:
+			operation.Parameters = append(operation.Parameters, openAPIMatcherParameters("query", matcher, false)...)
+		case schemeMatcher:
+			for scheme := range matcher {
+				operation.Schemes = append(operation.Schemes, scheme)
+			}
+			sort.Strings(operation.Schemes)
+		}
+	}
+
+	documented, ok := route.(interface{ GetDoc() RouteDoc })
+	if !ok {
+		return operation
+	}
+
+	doc := documented.GetDoc()
+	operation.Summary = doc.Summary
+	operation.Description = doc.Description
+	operation.Tags = doc.Tags
+
+	if doc.Request != nil {
+		operation.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content: map[string]openAPIMediaType{
+				mediaTypeJSON: {Schema: openAPISchemaOf(reflect.TypeOf(doc.Request), map[reflect.Type]bool{})},
+			},
+		}
+	}
+
+	if doc.Response != nil {
+		operation.Responses["200"].Content = map[string]openAPIMediaType{
+			mediaTypeJSON: {Schema: openAPISchemaOf(reflect.TypeOf(doc.Response), map[reflect.Type]bool{})},
+		}
+	}
+
+	return operation
+}
+
+// openAPIPath converts a route path into the OpenAPI {param} form
+// and returns the path parameters.
+func openAPIPath(path string, paramTypes map[string]ParamType) (string, []*openAPIParameter) {
+	var indexies map[string]int
+	switch {
+	case containsWildcard(path):
+		indexies = varIndexies("*", path, "")
+	case containsRegex(path):
+		indexies = varIndexies("#", path, "var")
+	case containsVars(path):
+		indexies = varIndexies(":", path, "")
+	default:
+		return path, nil
+	}
+
+	names := make(map[int]string, len(indexies))
+	for name, index := range indexies {
+		names[index] = name
+	}
+
+	urlSeg := strings.Split(path, "/")
+	parameters := make([]*openAPIParameter, 0, len(names))
+	for k, v := range urlSeg {
+		name, found := names[k]
+		if !found {
+			continue
+		}
+
+		name = strings.TrimLeft(name, ":*")
+		urlSeg[k] = "{" + name + "}"
+
+		parameters = append(parameters, &openAPIParameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   openAPIPathSchema(v, paramTypes),
+		})
+	}
+
+	return strings.Join(urlSeg, "/"), parameters
+}
+
+// openAPIParamTypeFormats are the OpenAPI formats of the built-in param types.
+var openAPIParamTypeFormats = map[string]string{
+	"uuid": "uuid",
+	"date": "date",
+}
+
+func openAPIPathSchema(segment string, paramTypes map[string]ParamType) *openAPISchema {
+	if strings.HasPrefix(segment, "#") {
+		return &openAPISchema{Type: "string", Pattern: "^" + strings.TrimPrefix(segment, "#") + "$"}
+	}
+
+	name := strings.TrimPrefix(segment, ":")
+	if name == "number" {
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	}
+
+	if paramType, found := paramTypes[name]; found {
+		return &openAPISchema{Type: "string", Format: openAPIParamTypeFormats[name], Pattern: "^" + paramType.Pattern + "$"}
+	}
+
+	return &openAPISchema{Type: "string"}
+}
+
+func openAPIMatcherParameters(in string, m map[string]comparison, canonicalKey bool) []*openAPIParameter {
+	parameters := make([]*openAPIParameter, 0, len(m))
+	for name, cmp := range m {
+		if canonicalKey {
+			name = http.CanonicalHeaderKey(name)
+		}
+
+		schema := &openAPISchema{Type: "string"}
+		switch c := cmp.(type) {
+		case stringComparison:
+			if c.isNotEmpty() {
+				schema.Enum = []string{string(c)}
+			}
+		case regexComparsion:
+			schema.Pattern = c.r.String()
+		}
+
+		parameters = append(parameters, &openAPIParameter{
+			Name:     name,
+			In:       in,
+			Required: true,
+			Schema:   schema,
+		})
+	}
+
+	sort.Slice(parameters, func(i, j int) bool {
+		return parameters[i].Name < parameters[j].Name
+	})
+
+	return parameters
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// openAPISchemaOf derives the JSON schema of a go type.
+func openAPISchemaOf(t reflect.Type, seen map[reflect.Type]bool) *openAPISchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &openAPISchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &openAPISchema{Type: "number"}
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &openAPISchema{Type: "string", Format: "byte"}
+		}
+		return &openAPISchema{Type: "array", Items: openAPISchemaOf(t.Elem(), seen)}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: openAPISchemaOf(t.Elem(), seen)}
+	case reflect.Struct:
+		schema := &openAPISchema{Type: "object"}
+		if seen[t] {
+			return schema
+		}
+
+		seen[t] = true
+		defer delete(seen, t)
+
+		schema.Properties = map[string]*openAPISchema{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			name, options := field.Name, ""
+			if tag, found := field.Tag.Lookup("json"); found {
+				if tag == "-" {
+					continue
+				}
+				if idx := strings.Index(tag, ","); idx != -1 {
+					tag, options = tag[:idx], tag[idx:]
+				}
+				if tag != "" {
+					name = tag
+				}
+			}
+
+			schema.Properties[name] = openAPISchemaOf(field.Type, seen)
+			if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Ptr {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+
+		return schema
+	default:
+		return &openAPISchema{}
+	}
+}
@@ -0,0 +1,355 @@
+package mux
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportOpenAPI reads an OpenAPI 3 JSON document and registers a route per
+// operation. The handler of an operation is looked up by its operationId,
+// operations without a handler are registered with an error (see HasErrors).
+//
+// Path templates are converted into route paths, every param becomes a regex
+// segment built from its schema, e.g. /user/{id} with an integer id becomes
+// /user/#(-?[0-9]+). The vars are named after the params, e.g. GetVars(req).Get("id").
+//
+// If validate is false, required query and header params are added as matchers.
+// If validate is true, the request is validated against all params before the
+// handler runs and a bad request is passed as BindErrors (400) to the ErrorHandler.
+func (r *Router) ImportOpenAPI(filename string, handlers map[string]http.Handler, validate bool) error {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	return r.importOpenAPI(content, handlers, validate)
+}
+
+func (r *Router) importOpenAPI(content []byte, handlers map[string]http.Handler, validate bool) error {
+	doc := struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}{}
+
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return fmt.Errorf("mux: unsupported OpenAPI version %q", doc.OpenAPI)
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+
+		var shared []*openAPIParameter
+		if raw, found := item["parameters"]; found {
+			if err := json.Unmarshal(raw, &shared); err != nil {
+				return fmt.Errorf("mux: bad parameters of path %s (%v)", path, err)
+			}
+		}
+
+		keys := make([]string, 0, len(item))
+		for key := range item {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			method := strings.ToUpper(key)
+			if _, found := methods[method]; !found {
+				continue
+			}
+
+			raw := item[key]
+			operation := &openAPIOperation{}
+			if err := json.Unmarshal(raw, operation); err != nil {
+				return fmt.Errorf("mux: bad operation %s %s (%v)", method, path, err)
+			}
+
+			operation.Parameters = mergeOpenAPIParameters(shared, operation.Parameters)
+			r.importOpenAPIOperation(method, path, operation, handlers, validate)
+		}
+	}
+
+	return nil
+}
+
+// mergeOpenAPIParameters merges the path level params with the operation
+// level params, the operation level params take precedence.
+func mergeOpenAPIParameters(shared []*openAPIParameter, own []*openAPIParameter) []*openAPIParameter {
+	parameters := make([]*openAPIParameter, 0, len(shared)+len(own))
+	parameters = append(parameters, own...)
+
+Loop:
+	for _, s := range shared {
+		for _, o := range own {
+			if s.Name == o.Name && s.In == o.In {
+				continue Loop
+			}
+		}
+		parameters = append(parameters, s)
+	}
+
+	return parameters
+}
+
+func (r *Router) importOpenAPIOperation(method string, template string, operation *openAPIOperation, handlers map[string]http.Handler, validate bool) RouteInterface {
+	route := r.NewRoute()
+
+	path, names, err := routePathFromOpenAPI(template, operation.Parameters)
+	if err != nil {
+		route.SetMethodName(method)
+		route.Path(template)
+		route.SetError(NewBadRouteError(route, err.Error()))
+		return r.RegisterRoute(method, route)
+	}
+
+	route.Path(path)
+
+	if rt, ok := route.(*Route); ok {
+		rt.nameVars(names)
+		if operation.OperationID != "" {
+			rt.Name(operation.OperationID)
+		}
+		rt.Doc(RouteDoc{
+			Summary:     operation.Summary,
+			Description: operation.Description,
+			Tags:        operation.Tags,
+		})
+	}
+
+	if !validate {
+		for _, parameter := range operation.Parameters {
+			if !parameter.Required {
+				continue
+			}
+
+			if rt, ok := route.(*Route); ok {
+				switch parameter.In {
+				case "query":
+					rt.Queries(parameter.Name, "")
+				case "header":
+					rt.Headers(parameter.Name, "")
+				}
+			}
+		}
+	}
+
+	route = r.RegisterRoute(method, route)
+
+	handler, found := handlers[operation.OperationID]
+	if !found {
+		route.SetError(NewBadRouteError(route, fmt.Sprintf("no handler for operation %q", operation.OperationID)))
+		return route
+	}
+
+	if validate {
+		handler, err = r.openAPIValidator(operation.Parameters, handler)
+		if err != nil {
+			route.SetError(NewBadRouteError(route, err.Error()))
+			return route
+		}
+	}
+
+	route.Handler(handler)
+
+	return route
+}
+
+// routePathFromOpenAPI converts an OpenAPI path template into a route path
+// and returns the var names by segment index.
+func routePathFromOpenAPI(template string, parameters []*openAPIParameter) (string, map[int]string, error) {
+	schemas := map[string]*openAPISchema{}
+	for _, parameter := range parameters {
+		if parameter.In == "path" {
+			schemas[parameter.Name] = parameter.Schema
+		}
+	}
+
+	urlSeg := strings.Split(template, "/")
+	names := map[int]string{}
+	for k, v := range urlSeg {
+		if !strings.HasPrefix(v, "{") || !strings.HasSuffix(v, "}") {
+			continue
+		}
+
+		name := v[1 : len(v)-1]
+		if _, found := schemas[name]; !found {
+			return "", nil, fmt.Errorf("path param %q is not declared", name)
+		}
+
+		names[k] = name
+	}
+
+	if len(names) == 0 {
+		return template, names, nil
+	}
+
+	for k, v := range urlSeg {
+		name, found := names[k]
+		switch {
+		case found:
+			pattern := openAPISegmentPattern(schemas[name])
+			if _, err := regexp.Compile(pattern); err != nil {
+				return "", nil, fmt.Errorf("bad pattern of path param %q (%v)", name, err)
+			}
+			urlSeg[k] = "#(" + pattern + ")"
+		default:
+			urlSeg[k] = regexp.QuoteMeta(v)
+		}
+	}
+
+	return strings.Join(urlSeg, "/"), names, nil
+}
+
+func openAPISegmentPattern(schema *openAPISchema) string {
+	if schema == nil {
+		return "[^/]+"
+	}
+
+	if schema.Pattern != "" {
+		return strings.TrimSuffix(strings.TrimPrefix(schema.Pattern, "^"), "$")
+	}
+
+	switch schema.Type {
+	case "integer":
+		return "-?[0-9]+"
+	case "number":
+		return "-?[0-9]+(?:\\.[0-9]+)?"
+	case "boolean":
+		return "(?:true|false)"
+	}
+
+	if len(schema.Enum) != 0 {
+		enum := make([]string, len(schema.Enum))
+		for k, v := range schema.Enum {
+			enum[k] = regexp.QuoteMeta(v)
+		}
+		return "(?:" + strings.Join(enum, "|") + ")"
+	}
+
+	return "[^/]+"
+}
+
+// openAPIValidator validates the request against the params before the
+// handler is called. Invalid params are passed as BindErrors to the
+// error handler of the router. The patterns of the schemas are compiled once.
+func (r *Router) openAPIValidator(parameters []*openAPIParameter, handler http.Handler) (http.Handler, error) {
+	patterns := map[*openAPISchema]*regexp.Regexp{}
+	for _, parameter := range parameters {
+		for schema := parameter.Schema; schema != nil; schema = schema.Items {
+			if schema.Pattern == "" {
+				continue
+			}
+
+			regex, err := regexp.Compile(schema.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("bad pattern of param %q (%v)", parameter.Name, err)
+			}
+			patterns[schema] = regex
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		queries, err := extractQueries(req)
+		if err != nil {
+			queries = map[string][]string{}
+		}
+
+		errs := make(BindErrors, 0)
+		for _, parameter := range parameters {
+			var values []string
+			switch parameter.In {
+			case "path":
+				if value, found := GetVars(req)[parameter.Name]; found {
+					values = []string{value}
+				}
+			case "query":
+				values = queries[parameter.Name]
+			case "header":
+				values = req.Header[http.CanonicalHeaderKey(parameter.Name)]
+			default:
+				continue
+			}
+
+			if len(values) == 0 {
+				if parameter.Required {
+					errs = append(errs, newParamError(parameter.Name, "", "", ErrParamMissing))
+				}
+				continue
+			}
+
+			for _, value := range values {
+				if err := validateOpenAPIValue(parameter.Name, parameter.Schema, patterns, value); err != nil {
+					errs = append(errs, err)
+					break
+				}
+			}
+		}
+
+		if len(errs) != 0 {
+			r.handleError(w, req, errs)
+			return
+		}
+
+		handler.ServeHTTP(w, req)
+	}), nil
+}
+
+// validateOpenAPIValue checks a single value against the schema and its compiled pattern.
+func validateOpenAPIValue(name string, schema *openAPISchema, patterns map[*openAPISchema]*regexp.Regexp, value string) error {
+	if schema == nil {
+		return nil
+	}
+
+	var err error
+	switch schema.Type {
+	case "array":
+		return validateOpenAPIValue(name, schema.Items, patterns, value)
+	case "integer":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "number":
+		_, err = strconv.ParseFloat(value, 64)
+	case "boolean":
+		_, err = strconv.ParseBool(value)
+	}
+
+	if err != nil {
+		return newParamError(name, value, schema.Type, err)
+	}
+
+	if len(schema.Enum) != 0 {
+		found := false
+		for _, v := range schema.Enum {
+			if v == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return newParamError(name, value, fmt.Sprintf("value of %v", schema.Enum), errors.New("value is not in enum"))
+		}
+	}
+
+	if regex := patterns[schema]; regex != nil {
+		if !regex.MatchString(value) {
+			return newParamError(name, value, "value matching "+schema.Pattern, errors.New("value does not match pattern"))
+		}
+	}
+
+	return nil
+}
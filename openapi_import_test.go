@@ -0,0 +1,242 @@
+package mux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const openAPITestDocument = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "paths": {
+    "/users/{id}": {
+      "parameters": [
+        {"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}
+      ],
+      "get": {
+        "operationId": "user.show",
+        "parameters": [
+          {"name": "fields", "in": "query", "required": false, "schema": {"type": "array", "items": {"type": "string", "enum": ["id", "name"]}}},
+          {"name": "X-Tenant", "in": "header", "required": true, "schema": {"type": "string"}}
+        ]
+      }
+    },
+    "/articles/{slug}/comments/{page}": {
+      "get": {
+        "operationId": "comment.list",
+        "parameters": [
+          {"name": "slug", "in": "path", "required": true, "schema": {"type": "string", "pattern": "^[a-z0-9-]+$"}},
+          {"name": "page", "in": "path", "required": true, "schema": {"type": "integer"}},
+          {"name": "limit", "in": "query", "required": true, "schema": {"type": "integer"}},
+          {"name": "sort", "in": "query", "required": false, "schema": {"type": "string", "pattern": "^(asc|desc)$"}}
+        ]
+      }
+    },
+    "/health": {
+      "get": {"operationId": "health"}
+    }
+  }
+}`
+
+func TestRoutePathFromOpenAPI(t *testing.T) {
+
+	tests := []struct {
+		template   string
+		parameters []*openAPIParameter
+		expected   string
+	}{
+		{
+			template: "/health",
+			expected: "/health",
+		},
+		{
+			template: "/users/{id}",
+			parameters: []*openAPIParameter{
+				{Name: "id", In: "path", Schema: &openAPISchema{Type: "integer"}},
+			},
+			expected: "/users/#(-?[0-9]+)",
+		},
+		{
+			template: "/articles/{slug}/comments/{page}",
+			parameters: []*openAPIParameter{
+				{Name: "slug", In: "path", Schema: &openAPISchema{Type: "string", Pattern: "^[a-z-]+$"}},
+				{Name: "page", In: "path", Schema: &openAPISchema{Type: "integer"}},
+			},
+			expected: "/articles/#([a-z-]+)/comments/#(-?[0-9]+)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.template, func(t *testing.T) {
+			path, _, err := routePathFromOpenAPI(test.template, test.parameters)
+			if err != nil {
+				t.Fatalf("Unexpected error (%s)", err.Error())
+			}
+
+			if path != test.expected {
+				t.Errorf("Unexpected path (Expected: %s, Actucal: %s)", test.expected, path)
+			}
+		})
+	}
+}
+
+func TestRoutePathFromOpenAPIFail(t *testing.T) {
+	if _, _, err := routePathFromOpenAPI("/users/{id}", nil); err == nil {
+		t.Error("Expected a error")
+	}
+}
+
+func TestImportOpenAPI(t *testing.T) {
+
+	tests := []struct {
+		title      string
+		path       string
+		header     map[string]string
+		validate   bool
+		statusCode int
+		body       string
+	}{
+		{
+			title:      "Path with integer param",
+			path:       "/users/42",
+			header:     map[string]string{"X-Tenant": "acme"},
+			statusCode: http.StatusOK,
+			body:       "user.show 42",
+		},
+		{
+			title:      "Path with negative integer param",
+			path:       "/users/-1",
+			header:     map[string]string{"X-Tenant": "acme"},
+			statusCode: http.StatusOK,
+			body:       "user.show -1",
+		},
+		{
+			title:      "Missing required header",
+			path:       "/users/42",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			title:      "Missing required header (validate)",
+			path:       "/users/42",
+			validate:   true,
+			statusCode: http.StatusBadRequest,
+			body:       `param "X-Tenant" is missing`,
+		},
+		{
+			title:      "Bad enum value (validate)",
+			path:       "/users/42?fields=id,password",
+			header:     map[string]string{"X-Tenant": "acme"},
+			validate:   true,
+			statusCode: http.StatusBadRequest,
+			body:       `"password" is not a valid value of [id name]`,
+		},
+		{
+			title:      "Path with pattern param",
+			path:       "/articles/hello-world/comments/2?limit=10",
+			validate:   true,
+			statusCode: http.StatusOK,
+			body:       "comment.list hello-world 2",
+		},
+		{
+			title:      "Bad integer query (validate)",
+			path:       "/articles/hello-world/comments/2?limit=ten",
+			validate:   true,
+			statusCode: http.StatusBadRequest,
+			body:       `"ten" is not a valid integer`,
+		},
+		{
+			title:      "Bad pattern query (validate)",
+			path:       "/articles/hello-world/comments/2?limit=10&sort=up",
+			validate:   true,
+			statusCode: http.StatusBadRequest,
+			body:       `"up" is not a valid value matching ^(asc|desc)$`,
+		},
+	}
+
+	handler := func(name string, vars ...string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			values := []string{name}
+			for _, v := range vars {
+				values = append(values, GetVars(r).Get(v))
+			}
+			w.Write([]byte(strings.Join(values, " ")))
+		})
+	}
+
+	handlers := map[string]http.Handler{
+		"user.show":    handler("user.show", "id"),
+		"comment.list": handler("comment.list", "slug", "page"),
+		"health":       handler("health"),
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			r := Classic()
+			if err := r.importOpenAPI([]byte(openAPITestDocument), handlers, test.validate); err != nil {
+				t.Fatalf("Unexpected error (%s)", err.Error())
+			}
+
+			if ok, errs := r.HasErrors(); ok {
+				t.Fatalf("Unexpected route errors (%v)", errs)
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost"+test.path, nil)
+			for k, v := range test.header {
+				req.Header.Set(k, v)
+			}
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Code != test.statusCode || !strings.Contains(res.Body.String(), test.body) {
+				t.Errorf("Unexpected response (Status code: %d, Body: %s)", res.Code, res.Body.String())
+			}
+		})
+	}
+}
+
+func TestImportOpenAPIMissingHandler(t *testing.T) {
+	r := Classic()
+	if err := r.importOpenAPI([]byte(openAPITestDocument), map[string]http.Handler{}, false); err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	if ok, errs := r.HasErrors(); !ok || len(errs) != 3 {
+		t.Errorf("Unexpected route errors (%v)", errs)
+	}
+}
+
+func TestImportOpenAPIFail(t *testing.T) {
+	r := Classic()
+	if err := r.importOpenAPI([]byte(`{"swagger": "2.0"}`), nil, false); err == nil {
+		t.Error("Expected a error")
+	}
+
+	if err := r.ImportOpenAPI("does-not-exist.json", nil, false); err == nil {
+		t.Error("Expected a error")
+	}
+}
+
+func TestImportOpenAPIBadPattern(t *testing.T) {
+	doc := `{
+  "openapi": "3.0.3",
+  "paths": {
+    "/search": {
+      "get": {
+        "operationId": "search",
+        "parameters": [{"name": "q", "in": "query", "schema": {"type": "string", "pattern": "([a-z"}}]
+      }
+    }
+  }
+}`
+
+	r := Classic()
+	if err := r.importOpenAPI([]byte(doc), map[string]http.Handler{"search": http.NotFoundHandler()}, true); err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	if ok, errs := r.HasErrors(); !ok || len(errs) != 1 {
+		t.Errorf("Unexpected route errors (%v)", errs)
+	}
+}
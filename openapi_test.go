@@ -0,0 +1,143 @@
+package mux
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type openAPITestUser struct {
+	ID       int64    `json:"id"`
+	Name     string   `json:"name"`
+	Nickname string   `json:"nickname,omitempty"`
+	Tags     []string `json:"tags"`
+	password string
+}
+
+func TestOpenAPIPath(t *testing.T) {
+
+	tests := []struct {
+		path     string
+		expected string
+		schemas  []openAPISchema
+	}{
+		{
+			path:     "/api/echo",
+			expected: "/api/echo",
+		},
+		{
+			path:     "/user/:number/comment/:number",
+			expected: "/user/{number}/comment/{number1}",
+			schemas: []openAPISchema{
+				{Type: "integer", Format: "int64"},
+				{Type: "integer", Format: "int64"},
+			},
+		},
+		{
+			path:     "/user/:string",
+			expected: "/user/{string}",
+			schemas: []openAPISchema{
+				{Type: "string", Pattern: "^[a-zA-Z]{1,}$"},
+			},
+		},
+		{
+			path:     "/user/:uuid/:date",
+			expected: "/user/{uuid}/{date}",
+			schemas: []openAPISchema{
+				{Type: "string", Format: "uuid", Pattern: "^" + defaultParamTypes["uuid"].Pattern + "$"},
+				{Type: "string", Format: "date", Pattern: "^[0-9]{4}-[0-9]{2}-[0-9]{2}$"},
+			},
+		},
+		{
+			path:     "/assets/*filepath",
+			expected: "/assets/{filepath}",
+			schemas: []openAPISchema{
+				{Type: "string"},
+			},
+		},
+		{
+			path:     "/article/#([a-z]{1,})",
+			expected: "/article/{var}",
+			schemas: []openAPISchema{
+				{Type: "string", Pattern: "^([a-z]{1,})$"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			path, parameters := openAPIPath(test.path, defaultParamTypes)
+
+			if path != test.expected {
+				t.Errorf("Unexpected path (Expected: %s, Actucal: %s)", test.expected, path)
+			}
+
+			if len(parameters) != len(test.schemas) {
+				t.Fatalf("Unexpected count of parameters (%d)", len(parameters))
+			}
+
+			for k, parameter := range parameters {
+				if !reflect.DeepEqual(*parameter.Schema, test.schemas[k]) || parameter.In != "path" || !parameter.Required {
+					t.Errorf("Unexpected parameter (%v)", parameter)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	r := Classic()
+	testHandler := func(w http.ResponseWriter, r *http.Request) {}
+
+	r.Get("/user/:number", testHandler).(*Route).Name("user.show").Doc(RouteDoc{
+		Summary:  "Show a user",
+		Tags:     []string{"user"},
+		Response: openAPITestUser{},
+	}).Headers("X-Tenant", "")
+	r.Post("/user", testHandler).(*Route).Doc(RouteDoc{Request: &openAPITestUser{}}).Queries("notify", "true")
+	r.Get("/secure", testHandler).(*Route).Schemes("https")
+	r.Get("invalid", testHandler)
+
+	content, err := r.OpenAPI(OpenAPIInfo{Title: "Test", Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	doc := openAPIDocument{}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	if len(doc.Paths) != 3 {
+		t.Errorf("Unexpected count of paths (%d)", len(doc.Paths))
+	}
+
+	show := doc.Paths["/user/{number}"]["get"]
+	if show == nil || show.OperationID != "user.show" || show.Summary != "Show a user" {
+		t.Fatalf("Unexpected operation (%v)", show)
+	}
+
+	if len(show.Parameters) != 2 || show.Parameters[1].Name != "X-Tenant" || show.Parameters[1].In != "header" {
+		t.Errorf("Unexpected parameters (%v)", show.Parameters)
+	}
+
+	schema := show.Responses["200"].Content[mediaTypeJSON].Schema
+	if schema.Properties["id"].Type != "integer" || schema.Properties["tags"].Items.Type != "string" {
+		t.Errorf("Unexpected response schema (%v)", schema)
+	}
+
+	if !reflect.DeepEqual(schema.Required, []string{"id", "name", "tags"}) {
+		t.Errorf("Unexpected required properties (%v)", schema.Required)
+	}
+
+	create := doc.Paths["/user"]["post"]
+	if create == nil || create.RequestBody == nil || len(create.Parameters) != 1 || create.Parameters[0].Schema.Enum[0] != "true" {
+		t.Errorf("Unexpected operation (%v)", create)
+	}
+
+	secure := doc.Paths["/secure"]["get"]
+	if secure == nil || !reflect.DeepEqual(secure.Schemes, []string{"https"}) {
+		t.Errorf("Unexpected operation (%v)", secure)
+	}
+}
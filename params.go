@@ -0,0 +1,286 @@
+package mux
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrParamMissing is the cause of a ParamError for a missing var or query.
+var ErrParamMissing = errors.New("param is missing")
+
+// ParamError is returned by the typed accessors of Vars and queries
+// if a param is missing or can't be converted.
+//
+// A shared error handler can turn it into a response:
+//
+//     var paramErr *mux.ParamError
+//     if errors.As(err, &paramErr) {
+//         http.Error(w, paramErr.Error(), paramErr.StatusCode())
+//     }
+type ParamError struct {
+	// Name of the var or query
+	Name string
+	// Value which can't be converted
+	Value string
+	// Type the value should be converted to
+	Type string
+	// Err is the cause
+	Err error
+}
+
+func (e *ParamError) Error() string {
+	if e.Err == ErrParamMissing {
+		return fmt.Sprintf("mux: param %q is missing", e.Name)
+	}
+
+	return fmt.Sprintf("mux: param %q: %q is not a valid %s", e.Name, e.Value, e.Type)
+}
+
+// Unwrap returns the cause.
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the status code for the response (400 Bad Request).
+func (e *ParamError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+func newParamError(name, value, typ string, err error) *ParamError {
+	return &ParamError{Name: name, Value: value, Type: typ, Err: err}
+}
+
+// UUID is a RFC 4122 UUID, e.g. 6ba7b810-9dad-11d1-80b4-00c04fd430c8
+type UUID [16]byte
+
+// ParseUUID parses the canonical form of a UUID.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("mux: invalid UUID %q", s)
+	}
+
+	raw := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	if _, err := hex.Decode(u[:], []byte(raw)); err != nil {
+		return u, fmt.Errorf("mux: invalid UUID %q", s)
+	}
+
+	return u, nil
+}
+
+// String returns the canonical form of the UUID.
+func (u UUID) String() string {
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf)
+}
+
+func parseInt(name, value string) (int, error) {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, newParamError(name, value, "int", err)
+	}
+	return i, nil
+}
+
+func parseInt64(name, value string) (int64, error) {
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, newParamError(name, value, "int64", err)
+	}
+	return i, nil
+}
+
+func parseUint64(name, value string) (uint64, error) {
+	i, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, newParamError(name, value, "uint64", err)
+	}
+	return i, nil
+}
+
+func parseFloat64(name, value string) (float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, newParamError(name, value, "float64", err)
+	}
+	return f, nil
+}
+
+func parseBool(name, value string) (bool, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, newParamError(name, value, "bool", err)
+	}
+	return b, nil
+}
+
+func parseUUID(name, value string) (UUID, error) {
+	u, err := ParseUUID(value)
+	if err != nil {
+		return u, newParamError(name, value, "UUID", err)
+	}
+	return u, nil
+}
+
+func parseTime(name, value, layout string) (time.Time, error) {
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return t, newParamError(name, value, "time ("+layout+")", err)
+	}
+	return t, nil
+}
+
+func (v Vars) value(key string) (string, error) {
+	if value, found := v[key]; found && value != "" {
+		return value, nil
+	}
+
+	return "", newParamError(key, "", "", ErrParamMissing)
+}
+
+// Int returns the var as int.
+func (v Vars) Int(key string) (int, error) {
+	value, err := v.value(key)
+	if err != nil {
+		return 0, err
+	}
+	return parseInt(key, value)
+}
+
+// Int64 returns the var as int64.
+func (v Vars) Int64(key string) (int64, error) {
+	value, err := v.value(key)
+	if err != nil {
+		return 0, err
+	}
+	return parseInt64(key, value)
+}
+
+// Uint64 returns the var as uint64.
+func (v Vars) Uint64(key string) (uint64, error) {
+	value, err := v.value(key)
+	if err != nil {
+		return 0, err
+	}
+	return parseUint64(key, value)
+}
+
+// Float64 returns the var as float64.
+func (v Vars) Float64(key string) (float64, error) {
+	value, err := v.value(key)
+	if err != nil {
+		return 0, err
+	}
+	return parseFloat64(key, value)
+}
+
+// Bool returns the var as bool, see strconv.ParseBool
+func (v Vars) Bool(key string) (bool, error) {
+	value, err := v.value(key)
+	if err != nil {
+		return false, err
+	}
+	return parseBool(key, value)
+}
+
+// UUID returns the var as UUID.
+func (v Vars) UUID(key string) (UUID, error) {
+	value, err := v.value(key)
+	if err != nil {
+		return UUID{}, err
+	}
+	return parseUUID(key, value)
+}
+
+// Time returns the var as time, parsed with the layout.
+func (v Vars) Time(key string, layout string) (time.Time, error) {
+	value, err := v.value(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseTime(key, value, layout)
+}
+
+func (q queries) value(key string) (string, error) {
+	if values, found := q[key]; found && len(values) != 0 && values[0] != "" {
+		return values[0], nil
+	}
+
+	return "", newParamError(key, "", "", ErrParamMissing)
+}
+
+// Int returns the first value of the query as int.
+func (q queries) Int(key string) (int, error) {
+	value, err := q.value(key)
+	if err != nil {
+		return 0, err
+	}
+	return parseInt(key, value)
+}
+
+// Int64 returns the first value of the query as int64.
+func (q queries) Int64(key string) (int64, error) {
+	value, err := q.value(key)
+	if err != nil {
+		return 0, err
+	}
+	return parseInt64(key, value)
+}
+
+// Uint64 returns the first value of the query as uint64.
+func (q queries) Uint64(key string) (uint64, error) {
+	value, err := q.value(key)
+	if err != nil {
+		return 0, err
+	}
+	return parseUint64(key, value)
+}
+
+// Float64 returns the first value of the query as float64.
+func (q queries) Float64(key string) (float64, error) {
+	value, err := q.value(key)
+	if err != nil {
+		return 0, err
+	}
+	return parseFloat64(key, value)
+}
+
+// Bool returns the first value of the query as bool, see strconv.ParseBool
+func (q queries) Bool(key string) (bool, error) {
+	value, err := q.value(key)
+	if err != nil {
+		return false, err
+	}
+	return parseBool(key, value)
+}
+
+// UUID returns the first value of the query as UUID.
+func (q queries) UUID(key string) (UUID, error) {
+	value, err := q.value(key)
+	if err != nil {
+		return UUID{}, err
+	}
+	return parseUUID(key, value)
+}
+
+// Time returns the first value of the query as time, parsed with the layout.
+func (q queries) Time(key string, layout string) (time.Time, error) {
+	value, err := q.value(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseTime(key, value, layout)
+}
@@ -0,0 +1,98 @@
+package mux
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestVarsAccessors(t *testing.T) {
+	vars := Vars{
+		":number": "42",
+		":string": "golang",
+		":uuid":   "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		":date":   "2017-07-20",
+		":bool":   "true",
+	}
+
+	if i, err := vars.Int64(":number"); err != nil || i != 42 {
+		t.Errorf("Unexpected int64 (%d, %v)", i, err)
+	}
+
+	if i, err := vars.Int(":number"); err != nil || i != 42 {
+		t.Errorf("Unexpected int (%d, %v)", i, err)
+	}
+
+	if f, err := vars.Float64(":number"); err != nil || f != 42 {
+		t.Errorf("Unexpected float64 (%f, %v)", f, err)
+	}
+
+	if b, err := vars.Bool(":bool"); err != nil || !b {
+		t.Errorf("Unexpected bool (%v, %v)", b, err)
+	}
+
+	if u, err := vars.UUID(":uuid"); err != nil || u.String() != vars[":uuid"] {
+		t.Errorf("Unexpected UUID (%v, %v)", u, err)
+	}
+
+	if d, err := vars.Time(":date", "2006-01-02"); err != nil || !d.Equal(time.Date(2017, 7, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected time (%v, %v)", d, err)
+	}
+}
+
+func TestVarsAccessorsFail(t *testing.T) {
+	vars := Vars{":string": "golang"}
+
+	_, err := vars.Int64(":string")
+
+	var paramErr *ParamError
+	if !errors.As(err, &paramErr) || paramErr.Name != ":string" || paramErr.Value != "golang" || paramErr.StatusCode() != http.StatusBadRequest {
+		t.Fatalf("Unexpected error (%v)", err)
+	}
+
+	if _, err := vars.UUID(":number"); !errors.Is(err, ErrParamMissing) {
+		t.Errorf("Unexpected error (%v)", err)
+	}
+
+	if _, err := vars.UUID(":string"); err == nil {
+		t.Error("Expected a error")
+	}
+}
+
+func TestQueriesAccessors(t *testing.T) {
+	q := queries{
+		"limit":  {"10", "20"},
+		"active": {"1"},
+		"since":  {"2017-07-20T10:00:00Z"},
+	}
+
+	if i, err := q.Int("limit"); err != nil || i != 10 {
+		t.Errorf("Unexpected int (%d, %v)", i, err)
+	}
+
+	if b, err := q.Bool("active"); err != nil || !b {
+		t.Errorf("Unexpected bool (%v, %v)", b, err)
+	}
+
+	if s, err := q.Time("since", time.RFC3339); err != nil || s.Hour() != 10 {
+		t.Errorf("Unexpected time (%v, %v)", s, err)
+	}
+
+	if _, err := q.Uint64("offset"); !errors.Is(err, ErrParamMissing) {
+		t.Errorf("Unexpected error (%v)", err)
+	}
+
+	var nilQueries queries
+	if _, err := nilQueries.Int64("limit"); !errors.Is(err, ErrParamMissing) {
+		t.Errorf("Unexpected error (%v)", err)
+	}
+}
+
+func TestParseUUIDFail(t *testing.T) {
+	for _, v := range []string{"", "6ba7b810-9dad-11d1-80b4", "6ba7b810x9dad-11d1-80b4-00c04fd430c8", "zba7b810-9dad-11d1-80b4-00c04fd430c8"} {
+		if _, err := ParseUUID(v); err == nil {
+			t.Errorf("Unexpected valid UUID (%s)", v)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package mux
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParamType describes the type of a path var, e.g. :number
+type ParamType struct {
+	// Name used in the path without the colon, e.g. number
+	Name string
+	// Pattern the path segment has to match
+	Pattern string
+	// Parse converts the path segment into the typed value, see GetTypedVars.
+	// If Parse is nil the segment is used as string.
+	Parse func(string) (interface{}, error)
+	// builtin types are parsed on demand and never reject a request
+	builtin bool
+}
+
+// defaultParamTypes are the param types known by every router.
+var defaultParamTypes = map[string]ParamType{
+	"number": {
+		Name:    "number",
+		Pattern: "[0-9]{1,}",
+		Parse: func(s string) (interface{}, error) {
+			return strconv.ParseInt(s, 10, 64)
+		},
+		builtin: true,
+	},
+	"string": {
+		Name:    "string",
+		Pattern: "[a-zA-Z]{1,}",
+	},
+	"uuid": {
+		Name:    "uuid",
+		Pattern: "[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}",
+		Parse: func(s string) (interface{}, error) {
+			return ParseUUID(s)
+		},
+		builtin: true,
+	},
+	"slug": {
+		Name:    "slug",
+		Pattern: "[a-z0-9]+(?:-[a-z0-9]+)*",
+	},
+	"date": {
+		Name:    "date",
+		Pattern: "[0-9]{4}-[0-9]{2}-[0-9]{2}",
+		Parse: func(s string) (interface{}, error) {
+			return time.Parse("2006-01-02", s)
+		},
+		builtin: true,
+	},
+}
+
+var paramTypeNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+// RegisterParamType registers a param type which can be used in paths as :name.
+// Already registered types are replaced, routes which are already registered
+// keep the old type.
+//
+// For example:
+//
+//     r := mux.Classic()
+//     r.RegisterParamType("hex", "[0-9a-f]{1,}", func(s string) (interface{}, error) {
+//         return strconv.ParseUint(s, 16, 64)
+//     })
+//     r.Get("/color/:hex", colorHandler)
+//
+// The built-in types are :number, :string, :uuid, :slug and :date. They only
+// check the pattern, their values are parsed by GetTypedVars and stay strings
+// if they don't fit, e.g. a :number beyond int64. Vars of registered types
+// with a parse func are parsed before the handler is called, a parse error
+// is answered with 400 through the ErrorHandler.
+// Unknown names in paths aren't types, they match the segment as it is.
+func (r *Router) RegisterParamType(name string, pattern string, parse func(string) (interface{}, error)) error {
+	name = strings.TrimPrefix(name, ":")
+	if !paramTypeNameRegex.MatchString(name) {
+		return fmt.Errorf("mux: invalid param type name %q", name)
+	}
+
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("mux: invalid pattern of param type %q (%v)", name, err)
+	}
+
+	if r.paramTypes == nil {
+		r.paramTypes = make(map[string]ParamType, len(defaultParamTypes))
+		for k, v := range defaultParamTypes {
+			r.paramTypes[k] = v
+		}
+	}
+
+	r.paramTypes[name] = ParamType{
+		Name:    name,
+		Pattern: pattern,
+		Parse:   parse,
+	}
+
+	return nil
+}
+
+// getParamTypes returns the param types of the router.
+func (r *Router) getParamTypes() map[string]ParamType {
+	if r == nil || r.paramTypes == nil {
+		return defaultParamTypes
+	}
+
+	return r.paramTypes
+}
+
+// TypedVars holds the parsed route variables, see ParamType.Parse
+type TypedVars map[string]interface{}
+
+// Get returns the parsed value of the var.
+func (v TypedVars) Get(key string) interface{} {
+	return v[key]
+}
+
+// GetAll returns all parsed vars.
+func (v TypedVars) GetAll() map[string]interface{} {
+	return v
+}
@@ -0,0 +1,192 @@
+package mux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParamTypes(t *testing.T) {
+
+	tests := []struct {
+		title      string
+		route      string
+		path       string
+		statusCode int
+		key        string
+		expected   interface{}
+	}{
+		{
+			title:      "Number",
+			route:      "/user/:number",
+			path:       "/user/42",
+			statusCode: http.StatusOK,
+			key:        ":number",
+			expected:   int64(42),
+		},
+		{
+			title:      "String",
+			route:      "/user/:string",
+			path:       "/user/golang",
+			statusCode: http.StatusOK,
+			key:        ":string",
+			expected:   "golang",
+		},
+		{
+			title:      "UUID",
+			route:      "/user/:uuid",
+			path:       "/user/6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+			statusCode: http.StatusOK,
+			key:        ":uuid",
+			expected:   UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8},
+		},
+		{
+			title:      "Slug",
+			route:      "/article/:slug",
+			path:       "/article/hello-world-2",
+			statusCode: http.StatusOK,
+			key:        ":slug",
+			expected:   "hello-world-2",
+		},
+		{
+			title:      "Slug (bad)",
+			route:      "/article/:slug",
+			path:       "/article/-hello",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			title:      "Date",
+			route:      "/archive/:date",
+			path:       "/archive/2017-07-20",
+			statusCode: http.StatusOK,
+			key:        ":date",
+			expected:   time.Date(2017, 7, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			title:      "Date (unparseable)",
+			route:      "/archive/:date",
+			path:       "/archive/2017-02-31",
+			statusCode: http.StatusOK,
+			key:        ":date",
+			expected:   "2017-02-31",
+		},
+		{
+			title:      "Number beyond int64",
+			route:      "/user/:number",
+			path:       "/user/99999999999999999999999",
+			statusCode: http.StatusOK,
+			key:        ":number",
+			expected:   "99999999999999999999999",
+		},
+		{
+			title:      "Custom (unparseable)",
+			route:      "/color/:hex",
+			path:       "/color/fffffffffffffffffffff",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			title:      "Custom",
+			route:      "/color/:hex",
+			path:       "/color/ff",
+			statusCode: http.StatusOK,
+			key:        ":hex",
+			expected:   uint64(255),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			r := Classic()
+			err := r.RegisterParamType("hex", "[0-9a-f]{1,}", func(s string) (interface{}, error) {
+				return strconv.ParseUint(s, 16, 64)
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error (%s)", err.Error())
+			}
+
+			var value interface{}
+			r.Get(test.route, func(w http.ResponseWriter, req *http.Request) {
+				value = GetTypedVars(req).Get(test.key)
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost"+test.path, nil)
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Code != test.statusCode {
+				t.Fatalf("Unexpected status code (%d)", res.Code)
+			}
+
+			if value != test.expected {
+				t.Errorf("Unexpected typed var (Expected: %v, Actucal: %v)", test.expected, value)
+			}
+		})
+	}
+}
+
+func TestRegisterParamTypeFail(t *testing.T) {
+	r := Classic()
+
+	if err := r.RegisterParamType("1abc", "[a-z]", nil); err == nil {
+		t.Error("Expected a error for a bad name")
+	}
+
+	if err := r.RegisterParamType("abc", "[a-z", nil); err == nil {
+		t.Error("Expected a error for a bad pattern")
+	}
+}
+
+func TestUnknownParamType(t *testing.T) {
+	r := Classic()
+	route := r.Get("/user/:unknown", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("user"))
+	})
+
+	if route.HasError() {
+		t.Fatalf("Unexpected error (%v)", route.GetError())
+	}
+
+	tests := []struct {
+		path     string
+		expected int
+	}{
+		{path: "/user/:unknown", expected: http.StatusOK},
+		{path: "/user/42", expected: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost"+test.path, nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		if res.Code != test.expected {
+			t.Errorf("Unexpected status code of %s (Expected: %d, Actucal: %d)", test.path, test.expected, res.Code)
+		}
+	}
+}
+
+func TestTypedVarsWithoutParse(t *testing.T) {
+	r := Classic()
+
+	var typed TypedVars
+	route := r.Get("/user/:string", func(w http.ResponseWriter, req *http.Request) {
+		typed = GetTypedVars(req)
+	})
+
+	// built-in types are parsed on demand, registered types before the handler
+	r.RegisterParamType("hex", "[0-9a-f]{1,}", func(s string) (interface{}, error) {
+		return strconv.ParseUint(s, 16, 64)
+	})
+	if route.(*Route).HasTypedVars() || r.NewRoute().Path("/user/:number").(*Route).HasTypedVars() || !r.NewRoute().Path("/color/:hex").(*Route).HasTypedVars() {
+		t.Error("Unexpected typed vars of the routes")
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/user/abc", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if typed.Get(":string") != "abc" {
+		t.Errorf("Unexpected typed vars (%v)", typed)
+	}
+}
@@ -0,0 +1,129 @@
+package mux
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const mediaTypeProblemJSON = "application/problem+json"
+
+// Problem is a RFC 7807 problem details object.
+// Method, Path and Route are extension members.
+type Problem struct {
+	Type     string   `json:"type"`
+	Title    string   `json:"title"`
+	Status   int      `json:"status"`
+	Detail   string   `json:"detail,omitempty"`
+	Instance string   `json:"instance,omitempty"`
+	Method   string   `json:"method,omitempty"`
+	Path     string   `json:"path,omitempty"`
+	Route    string   `json:"route,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// NewProblem returns the problem details of the request with the given status.
+// The route is the name of the matched route, or its path if it has no name.
+func NewProblem(req *http.Request, status int, detail string) Problem {
+	problem := Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: req.URL.RequestURI(),
+		Method:   req.Method,
+		Path:     req.URL.Path,
+	}
+
+	if route := CurrentRoute(req); route != nil {
+		problem.Route = route.GetPath()
+		if named, ok := route.(interface{ GetName() string }); ok && named.GetName() != "" {
+			problem.Route = named.GetName()
+		}
+	}
+
+	return problem
+}
+
+// WriteProblem writes the problem as application/problem+json.
+func WriteProblem(w http.ResponseWriter, problem Problem) {
+	w.Header().Set("Content-Type", mediaTypeProblemJSON)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// ProblemErrorHandler writes the error as application/problem+json,
+// it can be used as Router.ErrorHandler
+func ProblemErrorHandler(w http.ResponseWriter, req *http.Request, err error) {
+	problem := NewProblem(req, ErrorStatus(err), ErrorMessage(err))
+	problem.Errors = errorDetails(err)
+	WriteProblem(w, problem)
+}
+
+// acceptsProblem returns true if the client prefers a JSON response
+// over a text response, e.g. a browser sends text/html with a higher quality.
+func acceptsProblem(req *http.Request) bool {
+	accept := req.Header.Get("Accept")
+	if accept == "" {
+		return true
+	}
+
+	problemQ, textQ := 0.0, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if value, found := params["q"]; found {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case mediaTypeProblemJSON, mediaTypeJSON, "application/*", "*/*":
+			if q > problemQ {
+				problemQ = q
+			}
+		case "text/html", "text/plain", "text/*":
+			if q > textQ {
+				textQ = q
+			}
+		}
+	}
+
+	return problemQ > 0 && problemQ >= textQ
+}
+
+// problemNotFoundHandler answers with a problem if the client accepts it.
+func problemNotFoundHandler(w http.ResponseWriter, req *http.Request) {
+	if !acceptsProblem(req) {
+		http.NotFound(w, req)
+		return
+	}
+
+	WriteProblem(w, NewProblem(req, http.StatusNotFound, fmt.Sprintf("No route matches %s %s", req.Method, req.URL.Path)))
+}
+
+// writeRedirect redirects to the location, the body is a problem
+// if the problem details are enabled and the client accepts it.
+func (r *Router) writeRedirect(w http.ResponseWriter, req *http.Request, location string, status int) {
+	if r.Observer != nil {
+		r.Observer.OnRedirect(req, location)
+	}
+
+	w.Header().Set("Location", location)
+
+	if !r.ProblemDetails || !acceptsProblem(req) {
+		w.WriteHeader(status)
+		return
+	}
+
+	WriteProblem(w, NewProblem(req, status, fmt.Sprintf("The resource has moved to %s", location)))
+}
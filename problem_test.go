@@ -0,0 +1,142 @@
+package mux
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestAcceptsProblem(t *testing.T) {
+
+	tests := []struct {
+		accept   string
+		expected bool
+	}{
+		{accept: "", expected: true},
+		{accept: "*/*", expected: true},
+		{accept: "application/json", expected: true},
+		{accept: "application/problem+json", expected: true},
+		{accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", expected: false},
+		{accept: "text/plain", expected: false},
+		{accept: "text/html;q=0.5, application/json", expected: true},
+		{accept: "application/json;q=0", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept", test.accept)
+
+			if acceptsProblem(req) != test.expected {
+				t.Errorf("Unexpected negotiation (Expected: %v)", test.expected)
+			}
+		})
+	}
+}
+
+func TestProblemDetails(t *testing.T) {
+
+	tests := []struct {
+		title       string
+		path        string
+		accept      string
+		statusCode  int
+		contentType string
+		problem     Problem
+	}{
+		{
+			title:       "Not found",
+			path:        "/unknown?page=1",
+			statusCode:  http.StatusNotFound,
+			contentType: mediaTypeProblemJSON,
+			problem: Problem{
+				Type:     "about:blank",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "No route matches GET /unknown",
+				Instance: "/unknown?page=1",
+				Method:   http.MethodGet,
+				Path:     "/unknown",
+			},
+		},
+		{
+			title:       "Not found (browser)",
+			path:        "/unknown",
+			accept:      "text/html,*/*;q=0.8",
+			statusCode:  http.StatusNotFound,
+			contentType: "text/plain; charset=utf-8",
+		},
+		{
+			title:       "Redirect",
+			path:        "/api/../user/1",
+			statusCode:  http.StatusMovedPermanently,
+			contentType: mediaTypeProblemJSON,
+			problem: Problem{
+				Type:     "about:blank",
+				Title:    "Moved Permanently",
+				Status:   http.StatusMovedPermanently,
+				Detail:   "The resource has moved to /user/1",
+				Instance: "/api/../user/1",
+				Method:   http.MethodGet,
+				Path:     "/api/../user/1",
+			},
+		},
+		{
+			title:       "Handler error",
+			path:        "/user/1",
+			statusCode:  http.StatusConflict,
+			contentType: mediaTypeProblemJSON,
+			problem: Problem{
+				Type:     "about:blank",
+				Title:    "Conflict",
+				Status:   http.StatusConflict,
+				Detail:   "User is locked",
+				Instance: "/user/1",
+				Method:   http.MethodGet,
+				Path:     "/user/1",
+				Route:    "user.show",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			r := Classic()
+			r.ProblemDetails = true
+			r.GetE("/user/:number", func(w http.ResponseWriter, req *http.Request) error {
+				return NewHTTPError(http.StatusConflict, "User is locked", errors.New("lock held"))
+			}).(*Route).Name("user.show")
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.URL, _ = url.Parse(test.path)
+			if test.accept != "" {
+				req.Header.Set("Accept", test.accept)
+			}
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Code != test.statusCode || res.Header().Get("Content-Type") != test.contentType {
+				t.Fatalf("Unexpected response (Status code: %d, Content type: %s)", res.Code, res.Header().Get("Content-Type"))
+			}
+
+			if test.contentType != mediaTypeProblemJSON {
+				return
+			}
+
+			problem := Problem{}
+			if err := json.Unmarshal(res.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("Unexpected error (%s)", err.Error())
+			}
+
+			if problem.Type != test.problem.Type || problem.Title != test.problem.Title || problem.Status != test.problem.Status ||
+				problem.Detail != test.problem.Detail || problem.Instance != test.problem.Instance || problem.Method != test.problem.Method ||
+				problem.Path != test.problem.Path || problem.Route != test.problem.Route {
+				t.Errorf("Unexpected problem (%+v)", problem)
+			}
+		})
+	}
+}
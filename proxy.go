@@ -0,0 +1,317 @@
+package mux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultProxyMaxFails is the count of failures until a target is marked unhealthy.
+	DefaultProxyMaxFails = 3
+	// DefaultProxyFailTimeout is the duration a target stays unhealthy.
+	DefaultProxyFailTimeout = 10 * time.Second
+)
+
+// ProxyOptions configures the routes of Router.Proxy
+type ProxyOptions struct {
+	// Path template of the targets, the vars of the route are referenced in braces,
+	// e.g. /v2/users/{:number} or /static/{*filepath}
+	// The path of the request is used if it's empty. The path and the vars
+	// keep the case of the client.
+	Rewrite string
+	// Balancer selects the target of a request, RoundRobin if nil.
+	Balancer Balancer
+	// Count of consecutive failures until a target is marked unhealthy,
+	// DefaultProxyMaxFails if zero.
+	MaxFails int
+	// Duration a target stays unhealthy, DefaultProxyFailTimeout if zero.
+	FailTimeout time.Duration
+	// Transport of the proxy, http.DefaultTransport if nil.
+	Transport http.RoundTripper
+}
+
+// ProxyTarget is a target of a proxy route.
+type ProxyTarget struct {
+	URL            *url.URL
+	active         int64
+	fails          int64
+	unhealthyUntil int64
+	proxy          *httputil.ReverseProxy
+}
+
+// Healthy returns false if the target failed too often, see ProxyOptions.MaxFails
+func (t *ProxyTarget) Healthy() bool {
+	return time.Now().UnixNano() >= atomic.LoadInt64(&t.unhealthyUntil)
+}
+
+// ActiveRequests returns the count of requests which are forwarded right now.
+func (t *ProxyTarget) ActiveRequests() int64 {
+	return atomic.LoadInt64(&t.active)
+}
+
+// Balancer selects the target of a request. The targets are the healthy
+// targets, or all targets if none is healthy.
+type Balancer interface {
+	Next(req *http.Request, targets []*ProxyTarget) *ProxyTarget
+}
+
+// BalancerFunc is an adapter to use a func as Balancer
+type BalancerFunc func(req *http.Request, targets []*ProxyTarget) *ProxyTarget
+
+// Next calls f(req, targets).
+func (f BalancerFunc) Next(req *http.Request, targets []*ProxyTarget) *ProxyTarget {
+	return f(req, targets)
+}
+
+// RoundRobin returns a balancer which selects the targets in turn.
+func RoundRobin() Balancer {
+	var counter uint64
+	return BalancerFunc(func(req *http.Request, targets []*ProxyTarget) *ProxyTarget {
+		return targets[(atomic.AddUint64(&counter, 1)-1)%uint64(len(targets))]
+	})
+}
+
+// LeastConnections returns a balancer which selects the target with the
+// fewest active requests.
+func LeastConnections() Balancer {
+	return BalancerFunc(func(req *http.Request, targets []*ProxyTarget) *ProxyTarget {
+		selected := targets[0]
+		for _, target := range targets[1:] {
+			if target.ActiveRequests() < selected.ActiveRequests() {
+				selected = target
+			}
+		}
+		return selected
+	})
+}
+
+// ConsistentHash returns a balancer which selects the same target for the
+// same key as long as the target is healthy (rendezvous hashing).
+func ConsistentHash(key func(req *http.Request) string) Balancer {
+	return BalancerFunc(func(req *http.Request, targets []*ProxyTarget) *ProxyTarget {
+		k := key(req)
+
+		var selected *ProxyTarget
+		var max uint64
+		for _, target := range targets {
+			hash := fnv.New64a()
+			hash.Write([]byte(target.URL.String()))
+			hash.Write([]byte{0})
+			hash.Write([]byte(k))
+
+			if sum := hash.Sum64(); selected == nil || sum > max {
+				selected, max = target, sum
+			}
+		}
+		return selected
+	})
+}
+
+// HashVar returns a consistent hash balancer keyed by the route var, e.g. :number
+func HashVar(name string) Balancer {
+	return ConsistentHash(func(req *http.Request) string {
+		return GetVars(req).Get(name)
+	})
+}
+
+// HashHeader returns a consistent hash balancer keyed by the request header.
+func HashHeader(name string) Balancer {
+	return ConsistentHash(func(req *http.Request) string {
+		return req.Header.Get(name)
+	})
+}
+
+// Proxy registers routes for all standard methods which forward the requests
+// to the targets. The path of the targets can be built with the vars of the route:
+//
+//     api, _ := url.Parse("http://10.0.0.1:8080")
+//     backup, _ := url.Parse("http://10.0.0.2:8080")
+//
+//     r.Proxy("/api/users/:number", mux.ProxyOptions{
+//         Rewrite:  "/v2/users/{:number}",
+//         Balancer: mux.HashVar(":number"),
+//     }, api, backup)
+//
+// Targets are marked unhealthy for opts.FailTimeout after opts.MaxFails
+// consecutive failures (transport errors or 502, 503, 504 responses).
+// Transport errors are answered with 502 through the ErrorHandler.
+//
+// The X-Forwarded-For, X-Forwarded-Host and X-Forwarded-Proto headers are set,
+// X-Forwarded-For of the request is only kept if it comes from a trusted proxy
+// (see SetTrustedProxies). Upgrade requests, e.g. WebSockets, are forwarded
+// without the timeout of the route.
+func (r *Router) Proxy(path string, opts ProxyOptions, targets ...*url.URL) []RouteInterface {
+	p := newProxy(r, opts, targets)
+
+	routes := make([]RouteInterface, 0, len(methods))
+	for _, method := range sortedMethods() {
+		route := r.Handle(method, path, p)
+		if route.HasError() {
+			routes = append(routes, route)
+			continue
+		}
+
+		if len(targets) == 0 {
+			route.SetError(NewBadRouteError(route, "proxy has no targets"))
+		} else if err := checkTemplateVars(route, opts.Rewrite); err != nil {
+			route.SetError(NewBadRouteError(route, err.Error()))
+		}
+
+		routes = append(routes, route)
+	}
+
+	return routes
+}
+
+type proxy struct {
+	router      *Router
+	opts        ProxyOptions
+	targets     []*ProxyTarget
+	maxFails    int64
+	failTimeout time.Duration
+}
+
+func newProxy(router *Router, opts ProxyOptions, urls []*url.URL) *proxy {
+	p := &proxy{
+		router:      router,
+		opts:        opts,
+		maxFails:    int64(opts.MaxFails),
+		failTimeout: opts.FailTimeout,
+	}
+
+	if p.opts.Balancer == nil {
+		p.opts.Balancer = RoundRobin()
+	}
+
+	if p.maxFails == 0 {
+		p.maxFails = DefaultProxyMaxFails
+	}
+
+	if p.failTimeout == 0 {
+		p.failTimeout = DefaultProxyFailTimeout
+	}
+
+	for _, u := range urls {
+		target := &ProxyTarget{URL: u}
+		target.proxy = p.reverseProxy(target)
+		p.targets = append(p.targets, target)
+	}
+
+	return p
+}
+
+func (p *proxy) reverseProxy(target *ProxyTarget) *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			// forward the path and the vars with the case of the client, not the lowercased ones
+			if original := OriginalURL(pr.In); p.opts.Rewrite == "" && strings.EqualFold(original.Path, pr.In.URL.Path) {
+				pr.Out.URL.Path, pr.Out.URL.RawPath = original.Path, original.RawPath
+			}
+			pr.SetURL(target.URL)
+			if p.opts.Rewrite != "" {
+				pr.Out.URL.Path = joinURLPath(target.URL.Path, expandTemplate(p.opts.Rewrite, originalCaseVars(pr.In)))
+				pr.Out.URL.RawPath = ""
+			}
+			if p.router.isTrustedProxy(pr.In) {
+				pr.Out.Header["X-Forwarded-For"] = pr.In.Header["X-Forwarded-For"]
+			}
+			pr.SetXForwarded()
+			pr.Out.Header.Set("X-Forwarded-Proto", GetScheme(pr.In))
+		},
+		ModifyResponse: func(res *http.Response) error {
+			switch res.StatusCode {
+			case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+				p.fail(target)
+			default:
+				atomic.StoreInt64(&target.fails, 0)
+			}
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			if !errors.Is(err, context.Canceled) {
+				p.fail(target)
+			}
+			p.router.handleError(w, req, NewHTTPError(http.StatusBadGateway, "", fmt.Errorf("mux: proxy to %s: %w", target.URL.Host, err)))
+		},
+		Transport: p.opts.Transport,
+	}
+}
+
+// fail counts a failure of the target and marks it unhealthy after too many.
+func (p *proxy) fail(target *ProxyTarget) {
+	if atomic.AddInt64(&target.fails, 1) >= p.maxFails {
+		atomic.StoreInt64(&target.unhealthyUntil, time.Now().Add(p.failTimeout).UnixNano())
+		atomic.StoreInt64(&target.fails, 0)
+	}
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	healthy := make([]*ProxyTarget, 0, len(p.targets))
+	for _, target := range p.targets {
+		if target.Healthy() {
+			healthy = append(healthy, target)
+		}
+	}
+
+	if len(healthy) == 0 {
+		healthy = p.targets
+	}
+
+	target := p.opts.Balancer.Next(req, healthy)
+	atomic.AddInt64(&target.active, 1)
+	defer atomic.AddInt64(&target.active, -1)
+
+	target.proxy.ServeHTTP(w, req)
+}
+
+var templateVarRegex = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// expandTemplate replaces the var names in braces with the values of the vars,
+// e.g. /v2/users/{:number}
+func expandTemplate(template string, vars Vars) string {
+	return templateVarRegex.ReplaceAllStringFunc(template, func(match string) string {
+		return vars.Get(match[1 : len(match)-1])
+	})
+}
+
+// checkTemplateVars returns an error if the template references a var
+// which the route doesn't have.
+func checkTemplateVars(route RouteInterface, template string) error {
+	r, ok := route.(*Route)
+	if !ok {
+		return nil
+	}
+
+	for _, match := range templateVarRegex.FindAllStringSubmatch(template, -1) {
+		name := match[1]
+		if _, found := r.varIndexies[name]; !found && name != r.wildcard {
+			return fmt.Errorf("unknown var %q in template %s", name, template)
+		}
+	}
+
+	return nil
+}
+
+// joinURLPath joins the base path of a target with the path.
+func joinURLPath(base string, p string) string {
+	if base == "" || base == "/" {
+		return p
+	}
+
+	joined := path.Join(base, p)
+	if strings.HasSuffix(p, "/") && !strings.HasSuffix(joined, "/") {
+		joined += "/"
+	}
+
+	return joined
+}
@@ -0,0 +1,152 @@
+package mux
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// SetTrustedProxies sets the addresses of the proxies whose forwarding headers
+// are trusted, each address is an IP or a CIDR, e.g. 10.0.0.0/8
+//
+// The scheme of requests from trusted proxies is taken from the
+// Forwarded (proto=) or the X-Forwarded-Proto header, see GetScheme. The
+// client IP of the access log is the last untrusted hop of X-Forwarded-For.
+// The headers of all other requests are ignored.
+func (r *Router) SetTrustedProxies(addrs ...string) error {
+	networks := make([]*net.IPNet, 0, len(addrs))
+	for _, addr := range addrs {
+		if !strings.Contains(addr, "/") {
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				return fmt.Errorf("mux: invalid trusted proxy %q", addr)
+			}
+
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			addr = fmt.Sprintf("%s/%d", addr, bits)
+		}
+
+		_, network, err := net.ParseCIDR(addr)
+		if err != nil {
+			return fmt.Errorf("mux: invalid trusted proxy %q (%v)", addr, err)
+		}
+		networks = append(networks, network)
+	}
+
+	r.trustedProxies = networks
+	return nil
+}
+
+// isTrustedProxy returns true if the request comes from a trusted proxy.
+func (r *Router) isTrustedProxy(req *http.Request) bool {
+	return r.isTrustedIP(net.ParseIP(clientIP(req)))
+}
+
+// isTrustedIP returns true if the IP belongs to a trusted proxy.
+func (r *Router) isTrustedIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range r.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// forwardedClientIP returns the IP of the client. For requests from trusted
+// proxies it's the last hop of X-Forwarded-For which isn't a trusted proxy,
+// the hops before it can be spoofed by the client.
+func (r *Router) forwardedClientIP(req *http.Request) string {
+	ip := clientIP(req)
+	if len(r.trustedProxies) == 0 || !r.isTrustedProxy(req) {
+		return ip
+	}
+
+	var hops []string
+	for _, value := range req.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		parsed := net.ParseIP(hop)
+		if parsed == nil {
+			break
+		}
+
+		ip = hop
+		if !r.isTrustedIP(parsed) {
+			break
+		}
+	}
+
+	return ip
+}
+
+// addForwardedScheme stores the forwarded scheme of requests from
+// trusted proxies in the context.
+func (r *Router) addForwardedScheme(req *http.Request) *http.Request {
+	if len(r.trustedProxies) == 0 || !r.isTrustedProxy(req) {
+		return req
+	}
+
+	if scheme := forwardedScheme(req.Header); scheme != "" {
+		return AddScheme(req, scheme)
+	}
+
+	return req
+}
+
+// forwardedScheme returns the scheme of the Forwarded or the X-Forwarded-Proto header.
+// The last value is used if the headers have multiple values, it's set by the
+// trusted proxy itself, the values in front of it are controlled by the client.
+func forwardedScheme(header http.Header) string {
+	if forwarded := lastHeaderValue(header, "Forwarded"); forwarded != "" {
+		for _, pair := range strings.Split(forwarded, ";") {
+			key, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+			if found && strings.EqualFold(key, "proto") {
+				return validScheme(strings.Trim(value, `"`))
+			}
+		}
+	}
+
+	if proto := lastHeaderValue(header, "X-Forwarded-Proto"); proto != "" {
+		return validScheme(proto)
+	}
+
+	return ""
+}
+
+// lastHeaderValue returns the last element of the comma separated values of the header.
+func lastHeaderValue(header http.Header, key string) string {
+	values := header.Values(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	elements := strings.Split(values[len(values)-1], ",")
+	return strings.TrimSpace(elements[len(elements)-1])
+}
+
+func validScheme(scheme string) string {
+	scheme = strings.ToLower(scheme)
+	if scheme != "http" && scheme != "https" {
+		return ""
+	}
+
+	return scheme
+}
+
+// AbsoluteURL returns the absolute URL of the path on the host of the request,
+// with the scheme of GetScheme.
+func AbsoluteURL(req *http.Request, path string) string {
+	return GetScheme(req) + "://" + req.Host + path
+}
@@ -0,0 +1,155 @@
+package mux
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestForwardedScheme(t *testing.T) {
+
+	tests := []struct {
+		title    string
+		header   map[string]string
+		expected string
+	}{
+		{
+			title:    "Forwarded",
+			header:   map[string]string{"Forwarded": `for=192.0.2.60;proto=https;by=203.0.113.43`},
+			expected: "https",
+		},
+		{
+			title:    "Forwarded with quotes",
+			header:   map[string]string{"Forwarded": `for="[2001:db8::1]";Proto="HTTPS"`},
+			expected: "https",
+		},
+		{
+			title:    "Forwarded with multiple elements",
+			header:   map[string]string{"Forwarded": `for=192.0.2.60;proto=https, for=10.0.0.1;proto=http`},
+			expected: "http",
+		},
+		{
+			title:    "Forwarded has precedence",
+			header:   map[string]string{"Forwarded": "proto=http", "X-Forwarded-Proto": "https"},
+			expected: "http",
+		},
+		{
+			title:    "X-Forwarded-Proto",
+			header:   map[string]string{"X-Forwarded-Proto": "https"},
+			expected: "https",
+		},
+		{
+			title:    "X-Forwarded-Proto with multiple values",
+			header:   map[string]string{"X-Forwarded-Proto": "https, http"},
+			expected: "http",
+		},
+		{
+			title:    "Invalid scheme",
+			header:   map[string]string{"X-Forwarded-Proto": "javascript"},
+			expected: "",
+		},
+		{
+			title:    "No header",
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range test.header {
+				header.Set(k, v)
+			}
+
+			if scheme := forwardedScheme(header); scheme != test.expected {
+				t.Errorf("Unexpected scheme (Expected: %s, Actucal: %s)", test.expected, scheme)
+			}
+		})
+	}
+}
+
+func TestTrustedProxies(t *testing.T) {
+
+	tests := []struct {
+		title      string
+		remoteAddr string
+		proto      string
+		tls        bool
+		statusCode int
+	}{
+		{
+			title:      "Trusted proxy",
+			remoteAddr: "10.1.2.3:4000",
+			proto:      "https",
+			statusCode: http.StatusOK,
+		},
+		{
+			title:      "Trusted proxy by IP",
+			remoteAddr: "[::1]:4000",
+			proto:      "https",
+			statusCode: http.StatusOK,
+		},
+		{
+			title:      "Trusted proxy with spoofed value",
+			remoteAddr: "10.0.0.1:4000",
+			proto:      "https, http",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			title:      "Untrusted client",
+			remoteAddr: "192.0.2.1:4000",
+			proto:      "https",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			title:      "TLS connection",
+			remoteAddr: "192.0.2.1:4000",
+			tls:        true,
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			r := Classic()
+			if err := r.SetTrustedProxies("10.0.0.0/8", "::1"); err != nil {
+				t.Fatalf("Unexpected error (%s)", err.Error())
+			}
+
+			r.Get("/secure", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(AbsoluteURL(r, "/login")))
+			}).(*Route).Schemes("https")
+
+			req := httptest.NewRequest(http.MethodGet, "/secure", nil)
+			req.RemoteAddr = test.remoteAddr
+			req.TLS = nil
+			if test.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			if test.proto != "" {
+				req.Header.Set("X-Forwarded-Proto", test.proto)
+			}
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Code != test.statusCode {
+				t.Fatalf("Unexpected status code (%d)", res.Code)
+			}
+
+			if res.Code == http.StatusOK && res.Body.String() != "https://example.com/login" {
+				t.Errorf("Unexpected URL (%s)", res.Body.String())
+			}
+		})
+	}
+}
+
+func TestSetTrustedProxiesFail(t *testing.T) {
+	r := Classic()
+	for _, addr := range []string{"10.0.0.0/33", "localhost"} {
+		if err := r.SetTrustedProxies(addr); err == nil {
+			t.Errorf("Expected a error (%s)", addr)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package mux
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestBackend(t *testing.T, name string) (*httptest.Server, *url.URL) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s %s %s", name, r.URL.RequestURI(), r.Header.Get("X-Forwarded-For"), r.Header.Get("X-Forwarded-Proto"))
+	}))
+	t.Cleanup(backend.Close)
+
+	u, _ := url.Parse(backend.URL)
+	return backend, u
+}
+
+func TestProxy(t *testing.T) {
+	_, first := newTestBackend(t, "first")
+	_, second := newTestBackend(t, "second")
+
+	tests := []struct {
+		title    string
+		route    string
+		opts     ProxyOptions
+		path     string
+		expected []string
+	}{
+		{
+			title:    "Round robin",
+			route:    "/api/users/:number",
+			opts:     ProxyOptions{Rewrite: "/v2/users/{:number}"},
+			path:     "/api/users/42?fields=name",
+			expected: []string{"first /v2/users/42?fields=name 192.0.2.1 http", "second /v2/users/42?fields=name 192.0.2.1 http"},
+		},
+		{
+			title:    "Wildcard without rewrite",
+			route:    "/static/*filepath",
+			path:     "/static/css/app.css",
+			expected: []string{"first /static/css/app.css", "second /static/css/app.css"},
+		},
+		{
+			title:    "Original case without rewrite",
+			route:    "/static/*filepath",
+			path:     "/static/CSS/App.css",
+			expected: []string{"first /static/CSS/App.css", "second /static/CSS/App.css"},
+		},
+		{
+			title:    "Original case with rewrite",
+			route:    "/api/files/*filepath",
+			opts:     ProxyOptions{Rewrite: "/v2/{*filepath}"},
+			path:     "/api/files/Docs/ReadMe.MD",
+			expected: []string{"first /v2/Docs/ReadMe.MD", "second /v2/Docs/ReadMe.MD"},
+		},
+		{
+			title:    "Original case of vars with rewrite",
+			route:    "/api/users/:string/posts",
+			opts:     ProxyOptions{Rewrite: "/v2/users/{:string}"},
+			path:     "/API/Users/Alice/posts",
+			expected: []string{"first /v2/users/Alice", "second /v2/users/Alice"},
+		},
+		{
+			title:    "Consistent hash",
+			route:    "/api/users/:number",
+			opts:     ProxyOptions{Rewrite: "/users/{:number}", Balancer: HashVar(":number")},
+			path:     "/api/users/42",
+			expected: []string{"/users/42", "/users/42"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			r := Classic()
+			r.Proxy(test.route, test.opts, first, second)
+
+			if ok, errs := r.HasErrors(); ok {
+				t.Fatalf("Unexpected route errors (%v)", errs)
+			}
+
+			bodies := make([]string, len(test.expected))
+			for k, expected := range test.expected {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost"+test.path, nil)
+				req.RemoteAddr = "192.0.2.1:4000"
+				req.Header.Set("X-Forwarded-For", "203.0.113.7")
+				res := httptest.NewRecorder()
+				r.ServeHTTP(res, req)
+
+				bodies[k] = res.Body.String()
+				if res.Code != http.StatusOK || !strings.Contains(bodies[k], expected) {
+					t.Errorf("Unexpected response (Status code: %d, Body: %s)", res.Code, bodies[k])
+				}
+			}
+
+			if test.opts.Balancer != nil && strings.Fields(bodies[0])[0] != strings.Fields(bodies[1])[0] {
+				t.Errorf("Expected the same target (%v)", bodies)
+			}
+		})
+	}
+}
+
+func TestProxyUpgrade(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Unexpected error (%s)", err.Error())
+			return
+		}
+		defer conn.Close()
+
+		buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+		buf.Flush()
+		io.Copy(conn, buf)
+	}))
+	defer backend.Close()
+	target, _ := url.Parse(backend.URL)
+
+	r := Classic()
+	r.DefaultTimeout = 20 * time.Millisecond
+	r.Proxy("/ws", ProxyOptions{}, target)
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	fmt.Fprint(conn, "GET /ws HTTP/1.1\r\nHost: localhost\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+	reader := bufio.NewReader(conn)
+	res, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("Unexpected status code (%d)", res.StatusCode)
+	}
+
+	// the connection outlives the timeout of the route
+	time.Sleep(50 * time.Millisecond)
+	fmt.Fprint(conn, "ping")
+
+	echo := make([]byte, 4)
+	if _, err := io.ReadFull(reader, echo); err != nil || string(echo) != "ping" {
+		t.Errorf("Unexpected echo (%s, %v)", echo, err)
+	}
+}
+
+func TestProxyHealth(t *testing.T) {
+	down, downURL := newTestBackend(t, "down")
+	down.Close()
+	_, upURL := newTestBackend(t, "up")
+
+	r := Classic()
+	r.Proxy("/api/*path", ProxyOptions{MaxFails: 1, Balancer: RoundRobin()}, downURL, upURL)
+
+	statusCodes := make([]int, 4)
+	for k := range statusCodes {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost/api/users", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+		statusCodes[k] = res.Code
+	}
+
+	if statusCodes[0] != http.StatusBadGateway {
+		t.Errorf("Expected a bad gateway of the failed target (%v)", statusCodes)
+	}
+
+	for _, code := range statusCodes[1:] {
+		if code != http.StatusOK {
+			t.Errorf("Expected the healthy target only (%v)", statusCodes)
+		}
+	}
+}
+
+func TestProxyTrustedForwardedFor(t *testing.T) {
+	_, target := newTestBackend(t, "target")
+
+	r := Classic()
+	r.SetTrustedProxies("192.0.2.0/24")
+	r.Proxy("/api/*path", ProxyOptions{}, target)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/api/users", nil)
+	req.RemoteAddr = "192.0.2.1:4000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	req.Header.Set("X-Forwarded-Proto", "https")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if body := res.Body.String(); body != "target /api/users 203.0.113.7, 192.0.2.1 https" {
+		t.Errorf("Unexpected body (%s)", body)
+	}
+}
+
+func TestLeastConnections(t *testing.T) {
+	targets := []*ProxyTarget{{active: 3}, {active: 1}, {active: 2}}
+	if target := LeastConnections().Next(nil, targets); target != targets[1] {
+		t.Errorf("Unexpected target (%v)", target)
+	}
+}
+
+func TestProxyFail(t *testing.T) {
+	_, target := newTestBackend(t, "target")
+
+	r := Classic()
+	r.Proxy("/api/users/:number", ProxyOptions{Rewrite: "/users/{:string}"}, target)
+	r.Proxy("/api/articles/:number", ProxyOptions{})
+
+	if ok, errs := r.HasErrors(); !ok || len(errs) != 2*len(methods) {
+		t.Errorf("Unexpected route errors (%v)", errs)
+	}
+}
+
+func TestExpandTemplate(t *testing.T) {
+	vars := Vars{":number": "42", ":number1": "7", "*filepath": "css/app.css"}
+
+	if path := expandTemplate("/users/{:number}/comments/{:number1}/{*filepath}", vars); path != "/users/42/comments/7/css/app.css" {
+		t.Errorf("Unexpected path (%s)", path)
+	}
+}
@@ -0,0 +1,54 @@
+package mux
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// recoverPanic recovers a panic of the handler, reports it to the
+// PanicHandler and answers with 500 if nothing was written yet.
+// If the response was already started, it panics with http.ErrAbortHandler
+// after the report, so the server aborts the connection instead of passing
+// a truncated response as complete. http.ErrAbortHandler is not recovered,
+// it aborts the response on purpose.
+func (r *Router) recoverPanic(w *responseWriter, req *http.Request) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	if recovered == http.ErrAbortHandler {
+		panic(recovered)
+	}
+
+	stack := debug.Stack()
+	if p, ok := recovered.(*handlerPanic); ok {
+		// raised again by the timeout handler, the stack of the handler is reported
+		recovered, stack = p.recovered, p.stack
+	}
+
+	r.reportPanic(w, req, recovered, stack)
+
+	if w.wroteHeader {
+		panic(http.ErrAbortHandler)
+	}
+
+	r.handleError(w, req, NewHTTPError(http.StatusInternalServerError, "", fmt.Errorf("mux: panic: %v", recovered)))
+}
+
+// reportPanic passes the panic to the Observer and the PanicHandler,
+// it's logged if the router has no PanicHandler.
+func (r *Router) reportPanic(w http.ResponseWriter, req *http.Request, recovered interface{}, stack []byte) {
+	if r.Observer != nil {
+		r.Observer.OnPanic(req, recovered, stack)
+	}
+
+	if r.PanicHandler != nil {
+		r.PanicHandler(w, req, recovered, stack)
+		return
+	}
+
+	log.Printf("mux: panic serving %s %s: %v\n%s", req.Method, req.URL.Path, recovered, stack)
+}
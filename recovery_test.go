@@ -0,0 +1,110 @@
+package mux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecoverPanics(t *testing.T) {
+
+	tests := []struct {
+		title      string
+		handler    func(w http.ResponseWriter, r *http.Request)
+		statusCode int
+		body       string
+		aborted    bool
+	}{
+		{
+			title: "Panic before writing",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				panic("something went wrong")
+			},
+			statusCode: http.StatusInternalServerError,
+			body:       "Internal Server Error\n",
+		},
+		{
+			title: "Panic after writing",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+				w.Write([]byte("partial"))
+				panic("something went wrong")
+			},
+			statusCode: http.StatusAccepted,
+			body:       "partial",
+			aborted:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			r := Classic()
+			r.RecoverPanics = true
+
+			var recovered interface{}
+			var stack []byte
+			var route RouteInterface
+			r.PanicHandler = func(w http.ResponseWriter, req *http.Request, rec interface{}, s []byte) {
+				recovered, stack, route = rec, s, CurrentRoute(req)
+			}
+
+			r.Get("/panic", test.handler)
+
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost/panic", nil)
+			res := httptest.NewRecorder()
+			aborted := func() (aborted bool) {
+				defer func() {
+					aborted = recover() == http.ErrAbortHandler
+				}()
+				r.ServeHTTP(res, req)
+				return false
+			}()
+
+			if aborted != test.aborted {
+				t.Errorf("Unexpected abort of the response (Expected: %v, Actucal: %v)", test.aborted, aborted)
+			}
+
+			if res.Code != test.statusCode || res.Body.String() != test.body {
+				t.Errorf("Unexpected response (Status code: %d, Body: %s)", res.Code, res.Body.String())
+			}
+
+			if recovered != "something went wrong" || !strings.Contains(string(stack), "recovery_test.go") || route == nil || route.GetPath() != "/panic" {
+				t.Errorf("Unexpected panic report (%v, %v)", recovered, route)
+			}
+		})
+	}
+}
+
+func TestRecoverPanicsDisabled(t *testing.T) {
+	r := Classic()
+	r.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("something went wrong")
+	})
+
+	defer func() {
+		if recovered := recover(); recovered == nil {
+			t.Error("Expected a panic")
+		}
+	}()
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/panic", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestRecoverPanicsAbortHandler(t *testing.T) {
+	r := Classic()
+	r.RecoverPanics = true
+	r.Get("/abort", func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+
+	defer func() {
+		if recovered := recover(); recovered != http.ErrAbortHandler {
+			t.Errorf("Unexpected recovered value (%v)", recovered)
+		}
+	}()
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/abort", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+}
@@ -0,0 +1,344 @@
+package mux
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+)
+
+// RedirectQuery defines how the query of the request is passed to the target of a redirect.
+type RedirectQuery int
+
+const (
+	// RedirectQueryAppend appends the query of the request to the query of the target.
+	RedirectQueryAppend RedirectQuery = iota
+	// RedirectQueryDrop drops the query of the request.
+	RedirectQueryDrop
+	// RedirectQueryMerge merges the queries, values of the request replace
+	// the values of the target with the same key.
+	RedirectQueryMerge
+)
+
+// RedirectOptions configures the routes of Router.RedirectWithOptions
+type RedirectOptions struct {
+	// Query of the request, RedirectQueryAppend if zero.
+	Query RedirectQuery
+}
+
+type redirect struct {
+	router *Router
+	from   string
+	to     string
+	code   int
+	opts   RedirectOptions
+	route  RouteInterface
+}
+
+// Redirect registers routes for all standard methods which redirect to the target
+// without a handler. The target can be built with the vars of the route:
+//
+//     r.Redirect("/summer-sale", "/sale", http.StatusFound)
+//     r.Redirect("/blog/:number", "/articles/{:number}", http.StatusMovedPermanently)
+//     r.Redirect("/docs/*filepath", "https://docs.example.com/{*filepath}", http.StatusPermanentRedirect)
+//
+// The code has to be 301, 302, 303, 307 or 308. The query of the request is
+// appended to the target, see RedirectWithOptions. Redirects which lead back to
+// themselves, directly or over other redirects, are reported as route errors
+// (see HasErrors). Loops of targets with vars are detected with a sample
+// path which matches the route, e.g. /blog/:number to /blog/{:number}.
+func (r *Router) Redirect(from string, to string, code int) []RouteInterface {
+	return r.RedirectWithOptions(from, to, code, RedirectOptions{})
+}
+
+// RedirectWithOptions is like Redirect with the options of the query.
+func (r *Router) RedirectWithOptions(from string, to string, code int, opts RedirectOptions) []RouteInterface {
+	rd := &redirect{router: r, from: from, to: to, code: code, opts: opts}
+
+	routes := make([]RouteInterface, 0, len(methods))
+	for _, method := range sortedMethods() {
+		routes = append(routes, r.Handle(method, from, rd))
+	}
+
+	rd.route = routes[0]
+	if rd.route.HasError() {
+		return routes
+	}
+
+	err := checkTemplateVars(rd.route, to)
+	if err == nil && !isRedirectCode(code) {
+		err = fmt.Errorf("invalid redirect code %d", code)
+	}
+
+	if err == nil {
+		if chain := r.redirectLoop(rd); chain != nil {
+			err = fmt.Errorf("redirect loop %s", strings.Join(chain, " -> "))
+		}
+	}
+
+	if err != nil {
+		for _, route := range routes {
+			route.SetError(NewBadRouteError(route, err.Error()))
+		}
+		return routes
+	}
+
+	r.redirects = append(r.redirects, rd)
+	return routes
+}
+
+// LoadRedirects registers the redirects of the CSV records. The fields of a record
+// are from, to and the optional code, 301 if it's empty. Lines starting with # are comments:
+//
+//     # from,to,code
+//     /summer-sale,/sale,302
+//     /blog/:number,/articles/{:number}
+//
+// It returns errors of the CSV, errors of the redirects are reported by HasErrors.
+func (r *Router) LoadRedirects(in io.Reader, opts RedirectOptions) error {
+	reader := csv.NewReader(in)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("mux: invalid redirects (%v)", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(record) < 2 || len(record) > 3 {
+			return fmt.Errorf("mux: line %d: redirect needs from, to and an optional code", line)
+		}
+
+		code := http.StatusMovedPermanently
+		if len(record) == 3 && strings.TrimSpace(record[2]) != "" {
+			code, err = strconv.Atoi(strings.TrimSpace(record[2]))
+			if err != nil {
+				return fmt.Errorf("mux: line %d: invalid redirect code %q", line, record[2])
+			}
+		}
+
+		r.RedirectWithOptions(strings.TrimSpace(record[0]), strings.TrimSpace(record[1]), code, opts)
+	}
+}
+
+// LoadRedirectsFile registers the redirects of the CSV file, see LoadRedirects
+func (r *Router) LoadRedirectsFile(filename string, opts RedirectOptions) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return r.LoadRedirects(file, opts)
+}
+
+func isRedirectCode(code int) bool {
+	switch code {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+
+	return false
+}
+
+// redirectLoop returns the chain of paths if the target of the redirect
+// leads back to it, otherwise nil. The vars of the target are expanded
+// with a sample path of the route.
+func (r *Router) redirectLoop(rd *redirect) []string {
+	route, ok := rd.route.(*Route)
+	if !ok {
+		return nil
+	}
+
+	sample, ok := samplePath(route)
+	if !ok {
+		return nil
+	}
+
+	req := &http.Request{Method: http.MethodGet, URL: &url.URL{Path: sample}, Header: http.Header{}}
+	if route.Match(req) == nil {
+		return nil
+	}
+
+	chain := []string{rd.from}
+	target := expandTemplate(rd.to, route.ExtractVars(req))
+	for i := 0; i <= len(r.redirects); i++ {
+		u, err := url.Parse(target)
+		if err != nil || u.Host != "" {
+			return nil
+		}
+
+		path := u.Path
+		if !r.CaseSensitiveURL {
+			path = strings.ToLower(path)
+		}
+		chain = append(chain, path)
+
+		req := &http.Request{Method: http.MethodGet, URL: &url.URL{Path: path}, Header: http.Header{}}
+		next := r.matchRedirect(req, rd)
+		if next == nil {
+			return nil
+		}
+
+		if next == rd {
+			return chain
+		}
+
+		target = expandTemplate(next.to, next.route.ExtractVars(req))
+	}
+
+	return nil
+}
+
+// samplePath returns a path which matches the path of the route,
+// the vars and the regex are replaced with sample values.
+func samplePath(route *Route) (string, bool) {
+	switch route.kind {
+	case kindWildcardPath:
+		return strings.TrimSuffix(route.wildcardPrefix, "/") + "/sample", true
+	case kindNormalPath:
+		return route.GetPath(), true
+	}
+
+	paramTypes := route.router.getParamTypes()
+	segments := strings.Split(route.GetPath(), "/")
+	for k, segment := range segments {
+		var pattern string
+		switch {
+		case route.kind == kindRegexPath && strings.HasPrefix(segment, "#"):
+			pattern = segment[1:]
+		case route.kind == kindVarsPath && strings.HasPrefix(segment, ":"):
+			paramType, found := paramTypes[segment[1:]]
+			if !found {
+				continue
+			}
+			pattern = paramType.Pattern
+		default:
+			continue
+		}
+
+		value, ok := sampleString(pattern)
+		if !ok {
+			return "", false
+		}
+		segments[k] = value
+	}
+
+	return strings.Join(segments, "/"), true
+}
+
+// sampleString returns a short string which matches the pattern.
+func sampleString(pattern string) (string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+
+	var sample strings.Builder
+	if !writeSample(&sample, re.Simplify()) {
+		return "", false
+	}
+
+	return sample.String(), true
+}
+
+func writeSample(sample *strings.Builder, re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText,
+		syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary, syntax.OpStar, syntax.OpQuest:
+		return true
+	case syntax.OpLiteral:
+		sample.WriteString(string(re.Rune))
+		return true
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sample.WriteByte('a')
+		return true
+	case syntax.OpCharClass:
+		for _, candidate := range "a0A-_." {
+			for i := 0; i+1 < len(re.Rune); i += 2 {
+				if re.Rune[i] <= candidate && candidate <= re.Rune[i+1] {
+					sample.WriteRune(candidate)
+					return true
+				}
+			}
+		}
+		return false
+	case syntax.OpCapture, syntax.OpPlus:
+		return writeSample(sample, re.Sub[0])
+	case syntax.OpRepeat:
+		for i := 0; i < re.Min; i++ {
+			if !writeSample(sample, re.Sub[0]) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !writeSample(sample, sub) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpAlternate:
+		return writeSample(sample, re.Sub[0])
+	}
+
+	return false
+}
+
+// matchRedirect returns the first registered redirect or the candidate
+// which matches the request.
+func (r *Router) matchRedirect(req *http.Request, candidate *redirect) *redirect {
+	for _, rd := range r.redirects {
+		if rd.route.Match(req) != nil {
+			return rd
+		}
+	}
+
+	if candidate.route.Match(req) != nil {
+		return candidate
+	}
+
+	return nil
+}
+
+func (rd *redirect) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	rd.router.writeRedirect(w, req, rd.location(req), rd.code)
+}
+
+// location returns the target with the vars and the query of the request,
+// the vars keep the case of the client.
+func (rd *redirect) location(req *http.Request) string {
+	target := expandTemplate(rd.to, originalCaseVars(req))
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+
+	switch rd.opts.Query {
+	case RedirectQueryAppend:
+		u.RawQuery = joinQuery(u.RawQuery, req.URL.RawQuery)
+	case RedirectQueryMerge:
+		query := u.Query()
+		for key, values := range req.URL.Query() {
+			query[key] = values
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
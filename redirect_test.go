@@ -0,0 +1,142 @@
+package mux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRedirect(t *testing.T) {
+
+	tests := []struct {
+		title    string
+		method   string
+		path     string
+		code     int
+		location string
+	}{
+		{title: "Static", method: http.MethodGet, path: "/summer-sale", code: http.StatusFound, location: "/sale"},
+		{title: "Vars", method: http.MethodGet, path: "/blog/42", code: http.StatusMovedPermanently, location: "/articles/42"},
+		{title: "Wildcard", method: http.MethodPost, path: "/docs/guide/intro", code: http.StatusPermanentRedirect, location: "https://docs.example.com/guide/intro"},
+		{title: "Original case", method: http.MethodGet, path: "/Docs/Guide/ReadMe.MD", code: http.StatusPermanentRedirect, location: "https://docs.example.com/Guide/ReadMe.MD"},
+		{title: "Append query", method: http.MethodGet, path: "/promo?utm_source=mail", code: http.StatusMovedPermanently, location: "/sale?ref=promo&utm_source=mail"},
+		{title: "Drop query", method: http.MethodGet, path: "/old-shop?page=2", code: http.StatusMovedPermanently, location: "/shop"},
+		{title: "Merge query", method: http.MethodGet, path: "/search?q=go&sort=asc", code: http.StatusMovedPermanently, location: "/find?q=go&sort=asc&type=all"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			r := Classic()
+			r.Redirect("/summer-sale", "/sale", http.StatusFound)
+			r.Redirect("/blog/:number", "/articles/{:number}", http.StatusMovedPermanently)
+			r.Redirect("/docs/*filepath", "https://docs.example.com/{*filepath}", http.StatusPermanentRedirect)
+			r.Redirect("/promo", "/sale?ref=promo", http.StatusMovedPermanently)
+			r.RedirectWithOptions("/old-shop", "/shop", http.StatusMovedPermanently, RedirectOptions{Query: RedirectQueryDrop})
+			r.RedirectWithOptions("/search", "/find?type=all&sort=desc", http.StatusMovedPermanently, RedirectOptions{Query: RedirectQueryMerge})
+
+			if ok, errs := r.HasErrors(); ok {
+				t.Fatalf("Unexpected errors (%v)", errs)
+			}
+
+			req := httptest.NewRequest(test.method, "http://localhost"+test.path, nil)
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Code != test.code {
+				t.Errorf("Unexpected status code (Expected: %d, Actucal: %d)", test.code, res.Code)
+			}
+
+			if location := res.Header().Get("Location"); location != test.location {
+				t.Errorf("Unexpected location (Expected: %s, Actucal: %s)", test.location, location)
+			}
+		})
+	}
+}
+
+func TestRedirectFail(t *testing.T) {
+
+	tests := []struct {
+		title     string
+		redirects [][2]string
+		code      int
+	}{
+		{title: "Invalid code", redirects: [][2]string{{"/a", "/b"}}, code: http.StatusOK},
+		{title: "Unknown var", redirects: [][2]string{{"/a/:number", "/b/{:string}"}}, code: http.StatusFound},
+		{title: "Self loop", redirects: [][2]string{{"/a", "/a"}}, code: http.StatusFound},
+		{title: "Chain loop", redirects: [][2]string{{"/a", "/b"}, {"/b", "/c"}, {"/c", "/a?x=1"}}, code: http.StatusFound},
+		{title: "Loop over vars", redirects: [][2]string{{"/a/:number", "/b"}, {"/b", "/a/1"}}, code: http.StatusFound},
+		{title: "Self loop with vars", redirects: [][2]string{{"/blog/:number", "/blog/{:number}"}}, code: http.StatusMovedPermanently},
+		{title: "Chain loop with vars", redirects: [][2]string{{"/a/:slug", "/b/{:slug}"}, {"/b/:slug", "/a/{:slug}"}}, code: http.StatusFound},
+		{title: "Self loop with wildcard", redirects: [][2]string{{"/docs/*filepath", "/docs/{*filepath}"}}, code: http.StatusFound},
+		{title: "Self loop with regex", redirects: [][2]string{{"/post/#([0-9]{2,4})", "/post/{var}"}}, code: http.StatusFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			r := Classic()
+
+			var routes []RouteInterface
+			for _, redirect := range test.redirects {
+				routes = r.Redirect(redirect[0], redirect[1], test.code)
+			}
+
+			if !routes[0].HasError() {
+				t.Errorf("Expected an error of the last redirect")
+			}
+
+			if ok, errs := r.HasErrors(); !ok || len(errs) != len(routes) {
+				t.Errorf("Unexpected errors (%v)", errs)
+			}
+		})
+	}
+}
+
+func TestLoadRedirects(t *testing.T) {
+	r := Classic()
+
+	content := "# from,to,code\n/summer-sale,/sale,302\n/blog/:number, /articles/{:number}\n"
+	if err := r.LoadRedirects(strings.NewReader(content), RedirectOptions{}); err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	if ok, errs := r.HasErrors(); ok {
+		t.Fatalf("Unexpected errors (%v)", errs)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/blog/7", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusMovedPermanently || res.Header().Get("Location") != "/articles/7" {
+		t.Errorf("Unexpected redirect (%d, %s)", res.Code, res.Header().Get("Location"))
+	}
+
+	for _, content := range []string{"/a\n", "/a,/b,found\n", "/a,\"/b\n"} {
+		if err := Classic().LoadRedirects(strings.NewReader(content), RedirectOptions{}); err == nil {
+			t.Errorf("Expected an error (%s)", content)
+		}
+	}
+}
+
+func TestLoadRedirectsFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "redirects.csv")
+	if err := os.WriteFile(filename, []byte("/summer-sale,/sale,302\n"), 0644); err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	r := Classic()
+	if err := r.LoadRedirectsFile(filename, RedirectOptions{}); err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	if len(r.redirects) != 1 {
+		t.Errorf("Unexpected count of redirects (%d)", len(r.redirects))
+	}
+
+	if err := r.LoadRedirectsFile(filepath.Join(t.TempDir(), "missing.csv"), RedirectOptions{}); err == nil {
+		t.Error("Expected an error")
+	}
+}
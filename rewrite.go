@@ -0,0 +1,212 @@
+package mux
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RewriteRule rewrites the path of matching requests before the routes
+// are matched, see Router.Rewrite
+type RewriteRule struct {
+	from       string
+	to         string
+	regex      *regexp.Regexp
+	conditions []Matcher
+	last       bool
+	err        error
+}
+
+// Rewrite adds a rule which rewrites the path of the request internally,
+// the client isn't redirected. The path accepts the syntax of Route.Path,
+// the captures (vars, regex groups or the wildcard) are referenced in the
+// target by number, {0} is the whole path:
+//
+//     r.Rewrite("/old/:number", "/new/{1}")
+//     r.Rewrite("/blog/#([0-9]{4})/#([a-z-]+)", "/articles/{2}?year={1}").Last()
+//     r.Rewrite("/docs/*filepath", "/help/{1}").Host("docs.example.com")
+//
+// The rules are applied in order after the path is cleaned and lowercased
+// (unless the router is CaseSensitiveURL), all matching rules are applied
+// unless a rule is the last. The original URL is available with OriginalURL.
+// A query of the target is added to the query of the request, the captures
+// in the query of the target are query escaped.
+func (r *Router) Rewrite(from string, to string) *RewriteRule {
+	rule := &RewriteRule{from: from, to: to}
+
+	var pattern string
+	switch {
+	case containsWildcard(from):
+		prefix, _ := splitWildcard(from)
+		pattern = `^` + regexp.QuoteMeta(prefix) + `(?:/(.*))?$`
+	case containsRegex(from):
+		pattern = `^` + strings.Replace(from, "#", "", -1) + `$`
+	case containsVars(from):
+		pattern = newPathWithVarsMatcher(from, r.getParamTypes()).regex.String()
+	default:
+		pattern = `^` + regexp.QuoteMeta(from) + `$`
+	}
+
+	if rule.err == nil {
+		rule.regex, rule.err = regexp.Compile(pattern)
+	}
+
+	if rule.err == nil {
+		rule.err = rule.checkReferences()
+	}
+
+	r.rewrites = append(r.rewrites, rule)
+	return rule
+}
+
+// checkReferences returns an error if the target references a missing capture.
+func (rr *RewriteRule) checkReferences() error {
+	for _, match := range templateVarRegex.FindAllStringSubmatch(rr.to, -1) {
+		index, err := strconv.Atoi(match[1])
+		if err != nil || index < 0 || index > rr.regex.NumSubexp() {
+			return fmt.Errorf("unknown capture %s", match[0])
+		}
+	}
+
+	return nil
+}
+
+// Host adds a condition on the host of the request, the port is ignored.
+func (rr *RewriteRule) Host(host string) *RewriteRule {
+	return rr.addCondition(MatcherFunc(func(req *http.Request) bool {
+		h := req.Host
+		if hostname, _, err := net.SplitHostPort(h); err == nil {
+			h = hostname
+		}
+		return strings.EqualFold(h, host)
+	}))
+}
+
+// Headers adds a condition on the header values of the request,
+// see Route.Headers
+func (rr *RewriteRule) Headers(pairs ...string) *RewriteRule {
+	matcher, err := newHeaderMatcher(pairs...)
+	if err != nil {
+		if rr.err == nil {
+			rr.err = err
+		}
+		return rr
+	}
+
+	return rr.addCondition(matcher)
+}
+
+// MatcherFunc adds a custom condition.
+func (rr *RewriteRule) MatcherFunc(f MatcherFunc) *RewriteRule {
+	return rr.addCondition(f)
+}
+
+// Last stops the processing of the following rules if the rule is applied.
+func (rr *RewriteRule) Last() *RewriteRule {
+	rr.last = true
+	return rr
+}
+
+func (rr *RewriteRule) addCondition(m Matcher) *RewriteRule {
+	rr.conditions = append(rr.conditions, m)
+	return rr
+}
+
+// GetError returns the error of the rule, see Router.HasErrors
+func (rr *RewriteRule) GetError() error {
+	if rr.err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("Rewrite -> From: %s To: %s Error: %v", rr.from, rr.to, rr.err)
+}
+
+// apply returns the rewritten path if the rule matches.
+func (rr *RewriteRule) apply(req *http.Request, path string) (string, bool) {
+	if rr.err != nil {
+		return path, false
+	}
+
+	captures := rr.regex.FindStringSubmatch(path)
+	if captures == nil {
+		return path, false
+	}
+
+	for _, condition := range rr.conditions {
+		if !condition.Match(req) {
+			return path, false
+		}
+	}
+
+	vars := make(Vars, len(captures))
+	for k, capture := range captures {
+		vars[strconv.Itoa(k)] = capture
+	}
+
+	target, query, hasQuery := strings.Cut(rr.to, "?")
+	target = expandTemplate(target, vars)
+	if !hasQuery {
+		return target, true
+	}
+
+	escaped := make(Vars, len(vars))
+	for name, value := range vars {
+		escaped[name] = url.QueryEscape(value)
+	}
+
+	return target + "?" + expandTemplate(query, escaped), true
+}
+
+// applyRewrites returns the request with the rewritten URL.
+func (r *Router) applyRewrites(req *http.Request) *http.Request {
+	if len(r.rewrites) == 0 {
+		return req
+	}
+
+	path, query, rewritten := req.URL.Path, "", false
+	for _, rule := range r.rewrites {
+		target, applied := rule.apply(req, path)
+		if !applied {
+			continue
+		}
+
+		targetPath, targetQuery, _ := strings.Cut(target, "?")
+		path, query, rewritten = targetPath, joinQuery(query, targetQuery), true
+		if rule.last {
+			break
+		}
+	}
+
+	if !rewritten {
+		return req
+	}
+
+	req = AddOriginalURL(req, req.URL)
+
+	u := new(url.URL)
+	*u = *req.URL
+	u.Path = path
+	u.RawPath = ""
+	u.RawQuery = joinQuery(query, req.URL.RawQuery)
+
+	rewrittenReq := new(http.Request)
+	*rewrittenReq = *req
+	rewrittenReq.URL = u
+	return rewrittenReq
+}
+
+// joinQuery joins the raw queries, empty queries are omitted.
+func joinQuery(queries ...string) string {
+	parts := make([]string, 0, len(queries))
+	for _, query := range queries {
+		if query != "" {
+			parts = append(parts, query)
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
@@ -0,0 +1,114 @@
+package mux
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRewrite(t *testing.T) {
+
+	tests := []struct {
+		title    string
+		path     string
+		host     string
+		header   map[string]string
+		expected string
+	}{
+		{title: "Vars", path: "/old/42", expected: "/new/42 page= /old/42"},
+		{title: "Regex with query", path: "/blog/2020/hello-world?page=2", expected: "/articles/hello-world page=2 /blog/2020/hello-world"},
+		{title: "Wildcard with host condition", path: "/docs/guide/intro", host: "docs.example.com:8080", expected: "/help/guide/intro page= /docs/guide/intro"},
+		{title: "Wildcard without host condition", path: "/docs/guide/intro", host: "example.com", expected: "404 page not found\n"},
+		{title: "Header condition", path: "/beta", header: map[string]string{"X-Beta": "1"}, expected: "/new/1 page= /beta"},
+		{title: "Continue processing", path: "/legacy/7", expected: "/new/7 page= /legacy/7"},
+		{title: "Stop processing", path: "/stop/7", expected: "/old/7 page= /stop/7"},
+		{title: "Upper case path", path: "/OLD/42", expected: "/new/42 page= /OLD/42"},
+		{title: "No rule", path: "/new/1", expected: "/new/1 page= /new/1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			r := Classic()
+			r.Rewrite("/old/:number", "/new/{1}")
+			r.Rewrite("/blog/#([0-9]{4})/#([a-z-]+)", "/articles/{2}?year={1}").Last()
+			r.Rewrite("/docs/*filepath", "/help/{1}").Host("docs.example.com")
+			r.Rewrite("/beta", "/new/1").Headers("X-Beta", "1")
+			r.Rewrite("/legacy/:number", "/old/{1}")
+			r.Rewrite("/stop/:number", "/old/{1}").Last()
+			r.Rewrite("/old/:number", "/new/{1}")
+
+			if ok, errs := r.HasErrors(); ok {
+				t.Fatalf("Unexpected errors (%v)", errs)
+			}
+
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, "%s page=%s %s", r.URL.Path, r.URL.Query().Get("page"), OriginalURL(r).Path)
+			}
+			r.Get("/new/:number", handler)
+			r.Get("/old/:number", handler)
+			r.Get("/articles/:slug", handler)
+			r.Get("/help/*filepath", handler)
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost"+test.path, nil)
+			if test.host != "" {
+				req.Host = test.host
+			}
+			for k, v := range test.header {
+				req.Header.Set(k, v)
+			}
+
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Body.String() != test.expected {
+				t.Errorf("Unexpected body (Expected: %s, Actucal: %s)", test.expected, res.Body.String())
+			}
+		})
+	}
+}
+
+func TestRewriteQuery(t *testing.T) {
+	r := Classic()
+	r.Rewrite("/blog/#([0-9]{4})/#([a-z-]+)", "/articles/{2}?year={1}")
+
+	var query string
+	r.Get("/articles/:slug", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/blog/2020/hello?page=2", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if query != "year=2020&page=2" {
+		t.Errorf("Unexpected query (%s)", query)
+	}
+}
+
+func TestRewriteQueryEscape(t *testing.T) {
+	r := Classic()
+	r.Rewrite("/search/*filepath", "/find?q={1}")
+
+	var query string
+	r.Get("/find", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("q")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/search/a&b=c%23d", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if query != "a&b=c#d" {
+		t.Errorf("Unexpected query (%s)", query)
+	}
+}
+
+func TestRewriteFail(t *testing.T) {
+	r := Classic()
+	r.Rewrite("/old/#([0-9]+", "/new")
+	r.Rewrite("/old/:number", "/new/{2}")
+	r.Rewrite("/old", "/new").Headers("X-Beta")
+
+	if ok, errs := r.HasErrors(); !ok || len(errs) != 3 {
+		t.Errorf("Unexpected errors (%v)", errs)
+	}
+}
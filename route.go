@@ -3,7 +3,9 @@ package mux
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -12,6 +14,9 @@ const (
 	kindRegexPath
 )
 
+// kindWildcardPath ranks below all other kinds, see SortRoutes
+const kindWildcardPath = -1
+
 // RouteInterface that you can create your own custom route
 type RouteInterface interface {
 	HasVars() bool
@@ -50,6 +55,19 @@ type Route struct {
 	path string
 	// varIndexies used to extract vars
 	varIndexies map[string]int
+	// varTypes used to parse vars, keyed by segment index.
+	// Only types with a parse func are stored.
+	varTypes map[int]ParamType
+	// wildcard is the name of the trailing wildcard var, e.g. *filepath
+	wildcard string
+	// wildcardPrefix is the path in front of the wildcard
+	wildcardPrefix string
+	// doc used to generate the OpenAPI document
+	doc RouteDoc
+	// timeout of the handler, see Timeout
+	timeout time.Duration
+	// maxBodyBytes limits the request body, see MaxBodyBytes
+	maxBodyBytes int64
 
 	router *Router
 }
@@ -153,6 +171,20 @@ func (r *Route) GetName() string {
 	return r.name
 }
 
+// Doc attaches documentation to the route, see Router.OpenAPI.
+func (r *Route) Doc(doc RouteDoc) *Route {
+	if r.err == nil {
+		r.doc = doc
+	}
+
+	return r
+}
+
+// GetDoc returns the documentation of the route.
+func (r *Route) GetDoc() RouteDoc {
+	return r.doc
+}
+
 // addMatcher adds a matcher to the route.
 func (r *Route) addMatcher(m Matcher) RouteInterface {
 	if r.err == nil {
@@ -170,7 +202,11 @@ func (r *Route) addMatcher(m Matcher) RouteInterface {
 //     r.Path("/billing/").Handler(BillingHandler)
 //     r.Path("/user/:number/comment/:string").Handler(commentHandler)
 //     r.Path("/article/#([a-z]{,10})").Handler(articleHandler)
+//     r.Path("/assets/*filepath").Handler(assetsHandler)
 //
+// A trailing wildcard matches the prefix and all paths below it,
+// the var (e.g. *filepath) holds the rest of the path without the leading slash.
+// The prefix of a wildcard can't contain vars or regex.
 func (r *Route) Path(path string) RouteInterface {
 
 	if r.path != "" {
@@ -179,13 +215,26 @@ func (r *Route) Path(path string) RouteInterface {
 
 	var matcher Matcher
 	switch {
+	case containsWildcard(path):
+		prefix, name := splitWildcard(path)
+		if containsRegex(prefix) || containsVars(prefix) {
+			r.path = path
+			r.err = NewBadRouteError(r, "prefix of a wildcard can't contain vars or regex")
+			return r
+		}
+		matcher = pathWildcardMatcher(prefix)
+		r.wildcard = name
+		r.wildcardPrefix = prefix
+		r.kind = kindWildcardPath
 	case containsRegex(path):
 		matcher = newPathRegexMatcher(path)
 		r.extractVarsIndexies("#", path, "var")
 		r.kind = kindRegexPath
 	case containsVars(path):
-		matcher = newPathWithVarsMatcher(path)
+		paramTypes := r.router.getParamTypes()
+		matcher = newPathWithVarsMatcher(path, paramTypes)
 		r.extractVarsIndexies(":", path, "")
+		r.extractVarTypes(path, paramTypes)
 		r.kind = kindVarsPath
 	default:
 		matcher = pathMatcher(path)
@@ -204,6 +253,12 @@ func (r *Route) GetPath() string {
 }
 
 func (r *Route) extractVarsIndexies(prefix string, path string, name string) {
+	r.varIndexies = varIndexies(prefix, path, name)
+}
+
+// varIndexies maps the var names of a path to their segment index.
+// Duplicate names get a counter suffix, e.g. :number, :number1
+func varIndexies(prefix string, path string, name string) map[string]int {
 
 	urlSeg := strings.Split(path, "/")
 
@@ -222,16 +277,50 @@ func (r *Route) extractVarsIndexies(prefix string, path string, name string) {
 			}
 
 			count++
-			indexies[v+string(count)] = k
+			indexies[v+strconv.Itoa(count)] = k
+		}
+	}
+
+	return indexies
+}
+
+func (r *Route) extractVarTypes(path string, paramTypes map[string]ParamType) {
+	urlSeg := strings.Split(path, "/")
+
+	r.varTypes = make(map[int]ParamType, len(r.varIndexies))
+	for _, index := range r.varIndexies {
+		if paramType := paramTypes[strings.TrimPrefix(urlSeg[index], ":")]; paramType.Parse != nil {
+			r.varTypes[index] = paramType
 		}
 	}
+}
+
+// HasTypedVars returns true if a var of the route has a registered param type
+// with a parse func, these vars are parsed before the handler is called.
+// Built-in types are parsed on demand, see GetTypedVars.
+func (r *Route) HasTypedVars() bool {
+	for _, paramType := range r.varTypes {
+		if !paramType.builtin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nameVars renames the vars of the route, names are keyed by segment index.
+func (r *Route) nameVars(names map[int]string) {
+	indexies := make(map[string]int, len(names))
+	for index, name := range names {
+		indexies[name] = index
+	}
 
 	r.varIndexies = indexies
 }
 
 //HasVars check if path has any vars
 func (r *Route) HasVars() bool {
-	return len(r.varIndexies) != 0
+	return len(r.varIndexies) != 0 || r.wildcard != ""
 }
 
 type Vars map[string]string
@@ -259,9 +348,90 @@ func (r *Route) ExtractVars(req *http.Request) Vars {
 		vars[k] = urlSeg[v]
 	}
 
+	if r.wildcard != "" {
+		vars[r.wildcard] = r.wildcardValue(req.URL.Path)
+	}
+
 	return vars
 }
 
+// originalCaseVars returns the vars of the request with the case of the
+// original path, the router matches lowercased paths unless it's
+// CaseSensitiveURL. Vars of rewritten paths are returned as they are.
+func originalCaseVars(req *http.Request) Vars {
+	vars := GetVars(req)
+	route, ok := CurrentRoute(req).(*Route)
+	original := OriginalURL(req).Path
+	if !ok || original == req.URL.Path || !strings.EqualFold(original, req.URL.Path) {
+		return vars
+	}
+
+	originalSeg := strings.Split(original, "/")
+	urlSeg := strings.Split(req.URL.Path, "/")
+	if len(originalSeg) != len(urlSeg) {
+		return vars
+	}
+
+	result := make(Vars, len(vars))
+	for k, v := range vars {
+		result[k] = v
+	}
+
+	for name, index := range route.varIndexies {
+		if strings.EqualFold(originalSeg[index], urlSeg[index]) {
+			result[name] = originalSeg[index]
+		}
+	}
+
+	if value := vars[route.wildcard]; route.wildcard != "" && len(value) <= len(original) {
+		if suffix := original[len(original)-len(value):]; strings.EqualFold(suffix, value) {
+			result[route.wildcard] = suffix
+		}
+	}
+
+	return result
+}
+
+// wildcardValue returns the rest of the path behind the wildcard prefix.
+func (r *Route) wildcardValue(path string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(path, r.wildcardPrefix), "/")
+}
+
+// ExtractTypedVars parses all vars of the current path with their param
+// types, see Router.RegisterParamType. Vars without a parse func are strings,
+// vars of built-in types which can't be parsed stay strings too.
+func (r *Route) ExtractTypedVars(req *http.Request) (TypedVars, error) {
+
+	urlSeg := strings.Split(req.URL.Path, "/")
+
+	vars := TypedVars(map[string]interface{}{})
+	for k, v := range r.varIndexies {
+		paramType := r.varTypes[v]
+		if paramType.Parse == nil {
+			vars[k] = urlSeg[v]
+			continue
+		}
+
+		value, err := paramType.Parse(urlSeg[v])
+		if err != nil && paramType.builtin {
+			vars[k] = urlSeg[v]
+			continue
+		}
+
+		if err != nil {
+			return nil, newParamError(k, urlSeg[v], ":"+paramType.Name, err)
+		}
+
+		vars[k] = value
+	}
+
+	if r.wildcard != "" {
+		vars[r.wildcard] = r.wildcardValue(req.URL.Path)
+	}
+
+	return vars, nil
+}
+
 // Schemes adds a matcher for URL schemes.
 // It accepts a sequence of schemes to be matched, e.g.: "http", "https".
 func (r *Route) Schemes(schemes ...string) RouteInterface {
@@ -334,6 +504,27 @@ func (r *Route) HeadersRegex(pairs ...string) RouteInterface {
 	return r
 }
 
+// Queries adds a matcher for URL query values.
+// It accepts a sequence of key/value pairs to be matched. For example:
+//
+//     r := mux.Classic()
+//     r.Get("/articles", handler).(*mux.Route).Queries("format", "json", "page", "")
+//
+// If one of the value is an empty string, it will match any value if the key is set.
+func (r *Route) Queries(pairs ...string) RouteInterface {
+	if r.err != nil {
+		return r
+	}
+
+	matcher, err := newQueryMatcher(pairs...)
+	if err != nil {
+		r.err = err
+		return r
+	}
+
+	return r.addMatcher(matcher)
+}
+
 // MatcherFunc adds a custom function to be used as request matcher.
 func (r *Route) MatcherFunc(f MatcherFunc) RouteInterface {
 	return r.addMatcher(f)
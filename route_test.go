@@ -34,3 +34,68 @@ func TestSortMatchers(t *testing.T) {
 		t.Errorf("Unexpected ranking (Index 0: %d, Index 1: %d, Index 2: %d)", ms[0].Rank(), ms[1].Rank(), ms[2].Rank())
 	}
 }
+
+func TestRouteWildcard(t *testing.T) {
+
+	tests := []struct {
+		title    string
+		path     string
+		reqPath  string
+		matched  bool
+		expected string
+	}{
+		{title: "Nested", path: "/assets/*filepath", reqPath: "/assets/css/app.css", matched: true, expected: "css/app.css"},
+		{title: "Prefix", path: "/assets/*filepath", reqPath: "/assets", matched: true, expected: ""},
+		{title: "Prefix with slash", path: "/assets/*filepath", reqPath: "/assets/", matched: true, expected: ""},
+		{title: "Other prefix", path: "/assets/*filepath", reqPath: "/assetsx/app.css", matched: false},
+		{title: "Root", path: "/*filepath", reqPath: "/user/42", matched: true, expected: "user/42"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			route := NewRoute(nil).Path(test.path)
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost"+test.reqPath, nil)
+
+			if matched := route.Match(req) != nil; matched != test.matched {
+				t.Fatalf("Unexpected match (%v)", matched)
+			}
+
+			if !test.matched {
+				return
+			}
+
+			if route.Kind() != kindWildcardPath || !route.HasVars() {
+				t.Errorf("Unexpected route (%v)", route)
+			}
+
+			if value := route.ExtractVars(req).Get("*filepath"); value != test.expected {
+				t.Errorf("Unexpected var (Expected: %s, Actucal: %s)", test.expected, value)
+			}
+		})
+	}
+}
+
+func TestRouteWildcardFail(t *testing.T) {
+	route := NewRoute(nil).Path("/user/:number/*filepath")
+	if !route.HasError() {
+		t.Error("Expected a error")
+	}
+}
+
+func TestRouteDuplicateVars(t *testing.T) {
+	route := NewRoute(Classic()).Path("/user/:number/comment/:number/reply/:number")
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/user/1/comment/2/reply/3", nil)
+	vars := route.ExtractVars(req)
+
+	expected := Vars{":number": "1", ":number1": "2", ":number2": "3"}
+	if len(vars) != len(expected) {
+		t.Fatalf("Unexpected vars (%v)", vars)
+	}
+
+	for key, value := range expected {
+		if vars.Get(key) != value {
+			t.Errorf("Unexpected var %s (Expected: %s, Actucal: %s)", key, value, vars.Get(key))
+		}
+	}
+}
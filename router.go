@@ -1,10 +1,15 @@
 package mux
 
 import (
+	"context"
+	"net"
 	"net/http"
+	"net/url"
 	"path"
 	"sort"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
 // NewRouter returns a new router instance.
@@ -33,6 +38,33 @@ func NewRouter() *Router {
 type Router struct {
 	// Configurable Handler to be used when no route matches.
 	NotFoundHandler http.Handler
+	// Configurable func to write errors returned by handlers (see GetE)
+	// and errors of the router, e.g. invalid typed vars.
+	// See DefaultErrorHandler, JSONErrorHandler and HTMLErrorHandler
+	ErrorHandler func(http.ResponseWriter, *http.Request, error)
+	// This defines the flag for RFC 7807 (application/problem+json) responses
+	// of the router, e.g. not found, redirects and errors without ErrorHandler.
+	// It's only used if the Accept header of the request allows JSON.
+	ProblemDetails bool
+	// This defines the flag for recovering panics of the matched handlers,
+	// a recovered panic is answered with 500 through the ErrorHandler.
+	RecoverPanics bool
+	// Configurable func to report recovered panics, see RecoverPanics.
+	// CurrentRoute(req) returns the matched route.
+	PanicHandler func(w http.ResponseWriter, req *http.Request, recovered interface{}, stack []byte)
+	// Configurable observer which gets notified about the routing events,
+	// see Observer and Observers
+	Observer Observer
+	// Configurable exporter which enables the tracing of requests,
+	// see GetSpan, MemoryExporter and JSONLinesExporter
+	SpanExporter SpanExporter
+	// Configurable logger which gets an entry per served request.
+	// See NewSlogAccessLogger, NewCommonLogger and NewCombinedLogger
+	AccessLogger AccessLogger
+	// Default timeout of the handlers, see Route.Timeout
+	DefaultTimeout time.Duration
+	// Default limit of the request bodies, see Route.MaxBodyBytes
+	DefaultMaxBodyBytes int64
 	// Routes to be matched, in order.
 	routes map[string]routes
 	// This defines the flag for new routes.
@@ -47,6 +79,18 @@ type Router struct {
 	CaseSensitiveURL bool
 	// this builds a route
 	constructRoute func(*Router) RouteInterface
+	// see EnableCoverage
+	coverage *CoverageRecorder
+	// see EnableMetrics
+	metrics *Metrics
+	// see RegisterParamType
+	paramTypes map[string]ParamType
+	// see SetTrustedProxies
+	trustedProxies []*net.IPNet
+	// see Rewrite
+	rewrites []*RewriteRule
+	// see Redirect
+	redirects []*redirect
 }
 
 // UseRoute that you can use diffrent instances routes
@@ -55,27 +99,55 @@ func (r *Router) UseRoute(constructer func(*Router) RouteInterface) {
 }
 
 // triggerMatching matches registered routes against the request.
-func (r *Router) triggerMatching(req *http.Request) RouteInterface {
+// The attempts are recorded if coverage isn't nil.
+func (r *Router) triggerMatching(req *http.Request, coverage *CoverageRecorder) RouteInterface {
 
 	if routesForMethod, found := r.routes[req.Method]; found {
 		for _, route := range routesForMethod {
-			if route := route.Match(req); route != nil {
-				return route
+			matched := route.Match(req)
+
+			if coverage != nil {
+				coverage.recordAttempt(route, req, matched != nil)
+			}
+
+			if matched != nil {
+				return matched
 			}
 		}
 	}
 
+	if coverage != nil {
+		coverage.recordNotFound()
+	}
+
 	return nil
 }
 
-// ServeHTTP dispatches the handler registered in the matched route.
-//
-// When there is a match, the route variables can be retrieved calling
-// mux.GetVars(req).Get(":number") or mux.GetVars(req).GetAll()
-//
-// and the route queires can be retrieved calling
-// mux.GetQueries(req).Get(":number") or mux.GetQueries(req).GetAll()
-func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+// Match returns the registered route which ServeHTTP dispatches the request to,
+// if any. See Resolve
+func (r *Router) Match(req *http.Request) RouteInterface {
+	route, _ := r.Resolve(req)
+	return route
+}
+
+// Resolve returns the registered route which ServeHTTP dispatches the request to
+// and the request as it's matched. The request is normalised like in ServeHTTP
+// (forwarded scheme, rewrites, lower case path) on a copy, req isn't changed.
+// The route is nil if no route matches or ServeHTTP redirects to the clean path.
+func (r *Router) Resolve(req *http.Request) (RouteInterface, *http.Request) {
+	req, cleanedPath := r.normalize(req)
+	if cleanedPath != "" {
+		return nil, req
+	}
+
+	return r.triggerMatching(req, nil), req
+}
+
+// normalize returns the request as it's matched, with the forwarded scheme,
+// the rewritten URL and a lower case path unless CaseSensitiveURL.
+// The URL is copied before it's changed. If the path isn't clean, it returns
+// the request as it is and the clean path to redirect to.
+func (r *Router) normalize(req *http.Request) (*http.Request, string) {
 	if !r.SkipClean {
 
 		path := req.URL.Path
@@ -85,20 +157,117 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 		// Clean path to canonical form and redirect.
 		if cleanedPath := cleanPath(path); cleanedPath != path {
-			w.Header().Set("Location", cleanedPath)
-			w.WriteHeader(http.StatusMovedPermanently)
-			return
+			return req, cleanedPath
 		}
 	}
 
+	req = r.addForwardedScheme(req)
+
 	if !r.CaseSensitiveURL {
-		req.URL.Path = strings.ToLower(req.URL.Path)
+		if lower := strings.ToLower(req.URL.Path); lower != req.URL.Path {
+			req = AddOriginalURL(req, req.URL)
+
+			u := new(url.URL)
+			*u = *req.URL
+			u.Path = lower
+			u.RawPath = strings.ToLower(req.URL.RawPath)
+
+			lowered := new(http.Request)
+			*lowered = *req
+			lowered.URL = u
+			req = lowered
+		}
+	}
+
+	return r.applyRewrites(req), ""
+}
+
+// Walk calls fn for each registered route, grouped by method in
+// alphabetical order. Walk stops at the first error returned by fn.
+func (r *Router) Walk(fn func(method string, route RouteInterface) error) error {
+	methods := make([]string, 0, len(r.routes))
+	for method := range r.routes {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	for _, method := range methods {
+		for _, route := range r.routes[method] {
+			if err := fn(method, route); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ServeHTTP dispatches the handler registered in the matched route.
+//
+// When there is a match, the route variables can be retrieved calling
+// mux.GetVars(req).Get(":number") or mux.GetVars(req).GetAll()
+//
+// and the route queires can be retrieved calling
+// mux.GetQueries(req).Get(":number") or mux.GetQueries(req).GetAll()
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if r.AccessLogger == nil && r.metrics == nil && r.Observer == nil && r.SpanExporter == nil {
+		r.dispatch(w, req)
+		return
+	}
+
+	start := time.Now()
+	rw := newResponseWriter(w)
+
+	var span *Span
+	if r.SpanExporter != nil {
+		span = newSpan(req, start)
+		req = AddSpan(req, span)
+	}
+
+	if r.metrics != nil {
+		atomic.AddInt64(&r.metrics.inFlight, 1)
+		defer atomic.AddInt64(&r.metrics.inFlight, -1)
+	}
+
+	req = r.dispatch(rw, req)
+
+	if r.metrics != nil {
+		r.metrics.observe(req, rw.Status(), time.Since(start))
+	}
+
+	if r.AccessLogger != nil {
+		r.AccessLogger.Log(r.newAccessEntry(rw, req, start))
+	}
+
+	if r.Observer != nil {
+		r.Observer.OnComplete(req, CurrentRoute(req), rw.Status(), time.Since(start))
+	}
+
+	if span != nil {
+		r.finishSpan(span, req, rw.Status())
+	}
+}
+
+// dispatch serves the request and returns it with the context of the matched route.
+func (r *Router) dispatch(w http.ResponseWriter, req *http.Request) *http.Request {
+	req, cleanedPath := r.normalize(req)
+	if cleanedPath != "" {
+		r.writeRedirect(w, req, cleanedPath, http.StatusMovedPermanently)
+		return req
+	}
+
+	matchStart := time.Now()
+	route := r.triggerMatching(req, r.coverage)
+	if span := GetSpan(req); span != nil {
+		span.MatchDuration = time.Since(matchStart)
 	}
 
-	route := r.triggerMatching(req)
 	if route == nil {
+		if r.Observer != nil {
+			r.Observer.OnNotFound(req)
+		}
 		r.notFoundHandler().ServeHTTP(w, req)
-		return
+		return req
 	}
 
 	req = AddCurrentRoute(req, route)
@@ -108,15 +277,63 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		req = AddVars(req, route.ExtractVars(req))
 	}
 
+	if r.Observer != nil {
+		r.Observer.OnMatch(req, route)
+	}
+
+	if typed, ok := route.(interface {
+		HasTypedVars() bool
+		ExtractTypedVars(*http.Request) (TypedVars, error)
+	}); ok && typed.HasTypedVars() {
+		vars, err := typed.ExtractTypedVars(req)
+		if err != nil {
+			r.handleError(w, req, err)
+			return req
+		}
+		req = AddTypedVars(req, vars)
+	}
+
 	if !route.HasHandler() {
 		route.Handler(r.notFoundHandler())
 	}
 
-	route.GetHandler().ServeHTTP(w, req)
+	r.serveRoute(w, req, route)
+	return req
+}
+
+// serveRoute dispatches the handler of the matched route.
+func (r *Router) serveRoute(w http.ResponseWriter, req *http.Request, route RouteInterface) {
+	if r.RecoverPanics {
+		rw := newResponseWriter(w)
+		defer r.recoverPanic(rw, req)
+		w = rw
+	}
+
+	if span := GetSpan(req); span != nil {
+		start := time.Now()
+		defer func() {
+			span.HandlerDuration = time.Since(start)
+		}()
+	}
+
+	handler := route.GetHandler()
+	if rt, ok := route.(*Route); ok {
+		if timeout := rt.GetTimeout(); timeout > 0 {
+			handler = r.timeoutHandler(handler, timeout)
+		}
+		if limit := rt.GetMaxBodyBytes(); limit > 0 {
+			handler = r.maxBodyHandler(handler, limit)
+		}
+	}
+
+	handler.ServeHTTP(w, req)
 }
 
 func (r *Router) notFoundHandler() http.Handler {
 	if r.NotFoundHandler == nil {
+		if r.ProblemDetails {
+			return http.HandlerFunc(problemNotFoundHandler)
+		}
 		return http.NotFoundHandler()
 	}
 
@@ -218,23 +435,56 @@ func (r *Router) Head(path string, handlerFunc func(http.ResponseWriter, *http.R
 	return r.RegisterRoute(http.MethodHead, r.NewRoute().Path(path).HandlerFunc(handlerFunc))
 }
 
-// ListenAndServe listens on the TCP network address addr
-// and then calls Serve with handler to handle requests
-// on incoming connections.
-func (r *Router) ListenAndServe(port string, callback func(errs []error)) {
+// HandleFuncE registers a new route with a matcher for the URL path and
+// a handler which returns errors, see Router.ErrorHandler
+func (r *Router) HandleFuncE(method string, path string, handlerFunc HandlerFuncE) RouteInterface {
+	return r.HandleFunc(method, path, r.handlerFuncE(handlerFunc))
+}
 
-	ok, errs := r.HasErrors()
-	if  ok {
-		callback(errs)
-		return
-	}
+// GetE registers a new get route for the URL path
+// See Router.HandleFuncE()
+func (r *Router) GetE(path string, handlerFunc HandlerFuncE) RouteInterface {
+	return r.Get(path, r.handlerFuncE(handlerFunc))
+}
 
-	r.SortRoutes()
-	errs = append(errs, http.ListenAndServe(port, r))
+// PutE registers a new put route for the URL path
+// See Router.HandleFuncE()
+func (r *Router) PutE(path string, handlerFunc HandlerFuncE) RouteInterface {
+	return r.Put(path, r.handlerFuncE(handlerFunc))
+}
 
-	if 0 != len(errs) {
-		callback(errs)
-	}
+// PostE registers a new post route for the URL path
+// See Router.HandleFuncE()
+func (r *Router) PostE(path string, handlerFunc HandlerFuncE) RouteInterface {
+	return r.Post(path, r.handlerFuncE(handlerFunc))
+}
+
+// DeleteE registers a new delete route for the URL path
+// See Router.HandleFuncE()
+func (r *Router) DeleteE(path string, handlerFunc HandlerFuncE) RouteInterface {
+	return r.Delete(path, r.handlerFuncE(handlerFunc))
+}
+
+// OptionsE registers a new options route for the URL path
+// See Router.HandleFuncE()
+func (r *Router) OptionsE(path string, handlerFunc HandlerFuncE) RouteInterface {
+	return r.Options(path, r.handlerFuncE(handlerFunc))
+}
+
+// HeadE registers a new head route for the URL path
+// See Router.HandleFuncE()
+func (r *Router) HeadE(path string, handlerFunc HandlerFuncE) RouteInterface {
+	return r.Head(path, r.handlerFuncE(handlerFunc))
+}
+
+// ListenAndServe listens on the TCP network address addr
+// and then calls Serve with handler to handle requests
+// on incoming connections. The callback gets the errors
+// of the routes or of the server, see Serve. Unlike Serve it
+// doesn't handle signals, the process keeps their default behaviour.
+func (r *Router) ListenAndServe(port string, callback func(errs []error)) {
+	err := r.Serve(context.Background(), ServeOptions{Addr: port, noSignals: true})
+	passErrors(err, callback)
 }
 
 // HasErrors checks if any errors exists
@@ -251,10 +501,17 @@ func (r *Router) HasErrors() (bool, []error) {
 		}
 	}
 
+	for _, rule := range r.rewrites {
+		if err := rule.GetError(); err != nil {
+			hasError = true
+			errors = append(errors, err)
+		}
+	}
+
 	return hasError, errors
 }
 
-// SortRoutes sorts the routes (Rank: RegexPath, PathWithVars, PathNormal)
+// SortRoutes sorts the routes (Rank: RegexPath, PathWithVars, PathNormal, PathWildcard)
 func (r *Router) SortRoutes() {
 	for _, v := range r.routes {
 		for _, vv := range v {
@@ -563,3 +563,52 @@ func TestListenAndServeFail(t *testing.T) {
 		}
 	})
 }
+
+func TestMatchAndWalk(t *testing.T) {
+	router := Classic()
+	testHandler := func(w http.ResponseWriter, r *http.Request) {}
+
+	router.Post("/api/user", testHandler)
+	router.Get("/api/user/:number", testHandler)
+	router.Get("/api/echo", testHandler)
+
+	router.Rewrite("/legacy/:number", "/api/user/{1}")
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/API/User/1", nil)
+	if route := router.Match(req); route == nil || route.GetPath() != "/api/user/:number" {
+		t.Errorf("Unexpected matched route (%v)", route)
+	}
+
+	if req.URL.Path != "/API/User/1" {
+		t.Errorf("Unexpected changed path (%s)", req.URL.Path)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "http://localhost/legacy/7", nil)
+	route, resolved := router.Resolve(req)
+	if route == nil || route.ExtractVars(resolved).Get(":number") != "7" {
+		t.Errorf("Unexpected resolved route (%v)", route)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "http://localhost/api/../api/echo", nil)
+	if route := router.Match(req); route != nil {
+		t.Errorf("Unexpected matched route of a redirected path (%v)", route)
+	}
+
+	visited := make([]string, 0)
+	router.Walk(func(method string, route RouteInterface) error {
+		visited = append(visited, method+" "+route.GetPath())
+		return nil
+	})
+
+	expected := []string{"GET /api/user/:number", "GET /api/echo", "POST /api/user"}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Errorf("Unexpected walked routes (%v)", visited)
+	}
+
+	err := router.Walk(func(method string, route RouteInterface) error {
+		return errors.New("stop")
+	})
+	if err == nil {
+		t.Error("Expected a error")
+	}
+}
@@ -0,0 +1,205 @@
+package mux
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// DefaultShutdownTimeout is the deadline of the graceful shutdown, see ServeOptions
+const DefaultShutdownTimeout = 10 * time.Second
+
+// ServeOptions configures the server of Router.Serve
+//
+// The timeouts and MaxHeaderBytes are passed to the http.Server,
+// zero means no timeout like in the http.Server.
+type ServeOptions struct {
+	// TCP address to listen on, ":http" if empty
+	Addr              string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	// Deadline for the active connections after the shutdown started,
+	// the remaining connections are closed after it. DefaultShutdownTimeout if zero.
+	ShutdownTimeout time.Duration
+	// Signals which start the shutdown, SIGTERM and interrupt if empty.
+	Signals []os.Signal
+	// noSignals keeps the default signal handling of the process,
+	// used by ListenAndServe and ListenAndServeTLS.
+	noSignals bool
+}
+
+// Serve validates and sorts the routes like ListenAndServe, then it listens
+// on opts.Addr until the context is cancelled or a shutdown signal arrives.
+// The server is shut down gracefully, Serve returns after all active
+// requests are finished or opts.ShutdownTimeout exceeded.
+//
+//     ctx, cancel := context.WithCancel(context.Background())
+//     defer cancel()
+//
+//     if err := r.Serve(ctx, mux.ServeOptions{Addr: ":8080", ReadTimeout: 5 * time.Second}); err != nil {
+//         log.Fatal(err)
+//     }
+//
+// The error of invalid routes is a RouteErrors, a graceful shutdown returns nil.
+func (r *Router) Serve(ctx context.Context, opts ServeOptions) error {
+	if err := r.prepare(); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", opts.addr())
+	if err != nil {
+		return err
+	}
+
+	return r.serveListeners(ctx, opts, listener)
+}
+
+// ServeListener is like Serve but it accepts the connections of the listener,
+// e.g. a listener of the tests or an inherited one. opts.Addr is ignored.
+func (r *Router) ServeListener(ctx context.Context, listener net.Listener, opts ServeOptions) error {
+	if err := r.prepare(); err != nil {
+		return err
+	}
+
+	return r.serveListeners(ctx, opts, listener)
+}
+
+// serveListeners serves all listeners with one server until the first fails
+// or the server is shut down. If a listener fails, the server is shut down
+// and serveListeners returns after all listeners stopped.
+func (r *Router) serveListeners(ctx context.Context, opts ServeOptions, listeners ...net.Listener) error {
+	server := r.newServer(opts)
+
+	return serveGracefully(ctx, server, opts, func() error {
+		errc := make(chan error, len(listeners))
+		for _, listener := range listeners {
+			go func(listener net.Listener) {
+				errc <- server.Serve(listener)
+			}(listener)
+		}
+
+		err := <-errc
+		if !errors.Is(err, http.ErrServerClosed) {
+			shutdown(server, opts)
+		}
+
+		for i := 1; i < len(listeners); i++ {
+			<-errc
+		}
+		return err
+	})
+}
+
+// passErrors passes the error of Serve to the callback of ListenAndServe,
+// the callback isn't called after a graceful shutdown.
+func passErrors(err error, callback func(errs []error)) {
+	if err == nil {
+		return
+	}
+
+	var routeErrs RouteErrors
+	if errors.As(err, &routeErrs) {
+		callback(routeErrs)
+		return
+	}
+
+	callback([]error{err})
+}
+
+// prepare validates and sorts the routes before the router serves.
+func (r *Router) prepare() error {
+	if ok, errs := r.HasErrors(); ok {
+		return RouteErrors(errs)
+	}
+
+	r.SortRoutes()
+	return nil
+}
+
+// addr returns the TCP address of the options, ":http" if it's empty.
+func (opts ServeOptions) addr() string {
+	if opts.Addr == "" {
+		return ":http"
+	}
+
+	return opts.Addr
+}
+
+// newServer returns the configured server of the router.
+func (r *Router) newServer(opts ServeOptions) *http.Server {
+	return &http.Server{
+		Addr:              opts.addr(),
+		Handler:           r,
+		ReadTimeout:       opts.ReadTimeout,
+		ReadHeaderTimeout: opts.ReadHeaderTimeout,
+		WriteTimeout:      opts.WriteTimeout,
+		IdleTimeout:       opts.IdleTimeout,
+		MaxHeaderBytes:    opts.MaxHeaderBytes,
+	}
+}
+
+// serveGracefully runs serve until it fails, the context is cancelled or
+// a shutdown signal arrives, then the server is shut down.
+func serveGracefully(ctx context.Context, server *http.Server, opts ServeOptions, serve func() error) error {
+	if !opts.noSignals {
+		signals := opts.Signals
+		if len(signals) == 0 {
+			signals = []os.Signal{syscall.SIGTERM, os.Interrupt}
+		}
+
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, signals...)
+		defer stop()
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- serve()
+	}()
+
+	select {
+	case err := <-errc:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	if err := shutdown(server, opts); err != nil {
+		return err
+	}
+
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// shutdown shuts the server down gracefully, the remaining connections
+// are closed after opts.ShutdownTimeout.
+func shutdown(server *http.Server, opts ServeOptions) error {
+	timeout := opts.ShutdownTimeout
+	if timeout == 0 {
+		timeout = DefaultShutdownTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		server.Close()
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package mux
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestServe(t *testing.T) {
+	r := Classic()
+	r.Get("/echo", func(w http.ResponseWriter, r *http.Request) {})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := r.Serve(ctx, ServeOptions{Addr: "127.0.0.1:0"}); err != nil {
+		t.Errorf("Unexpected error (%s)", err.Error())
+	}
+}
+
+func TestServeGracefulShutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+	addr := listener.Addr().String()
+
+	r := Classic()
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	r.Get("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-release
+		w.Write([]byte("done"))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- r.ServeListener(ctx, listener, ServeOptions{ReadTimeout: time.Second, ShutdownTimeout: 5 * time.Second})
+	}()
+
+	responded := make(chan string, 1)
+	go func() {
+		res, err := http.Get("http://" + addr + "/slow")
+		if err != nil {
+			responded <- err.Error()
+			return
+		}
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		responded <- string(body)
+	}()
+
+	<-entered
+	cancel()
+
+	// the shutdown closes the listener before it waits for the active request
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			break
+		}
+		conn.Close()
+		runtime.Gosched()
+	}
+	close(release)
+
+	if body := <-responded; body != "done" {
+		t.Errorf("Unexpected response of the active request (%s)", body)
+	}
+
+	if err := <-served; err != nil {
+		t.Errorf("Unexpected error (%s)", err.Error())
+	}
+}
+
+func TestServeFail(t *testing.T) {
+	r := Classic()
+	r.Get("echo", func(w http.ResponseWriter, r *http.Request) {})
+
+	err := r.Serve(context.Background(), ServeOptions{Addr: "127.0.0.1:0"})
+	if errs, ok := err.(RouteErrors); !ok || len(errs) != 1 {
+		t.Errorf("Unexpected error (%v)", err)
+	}
+}
+
+func TestNewServer(t *testing.T) {
+	r := Classic()
+	server := r.newServer(ServeOptions{ReadTimeout: time.Second, WriteTimeout: 2 * time.Second, IdleTimeout: 3 * time.Second, MaxHeaderBytes: 1024})
+
+	if server.Addr != ":http" || server.Handler != r || server.ReadTimeout != time.Second || server.WriteTimeout != 2*time.Second || server.IdleTimeout != 3*time.Second || server.MaxHeaderBytes != 1024 {
+		t.Errorf("Unexpected server (%v)", server)
+	}
+}
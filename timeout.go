@@ -0,0 +1,264 @@
+package mux
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Timeout sets the duration after which the context of the request is cancelled
+// and the request is answered with 503 through the ErrorHandler:
+//
+//     r.Get("/report", reportHandler).(*mux.Route).Timeout(5 * time.Second)
+//
+// If the handler already started to write the response, it isn't interrupted
+// but its context is cancelled. Writes of the handler after a 503 return
+// http.ErrHandlerTimeout, a panic after the 503 is reported to the PanicHandler
+// or logged. A negative duration disables the DefaultTimeout of the router.
+// Upgrade requests, e.g. WebSockets, have no timeout, the connection outlives the handler.
+func (r *Route) Timeout(d time.Duration) *Route {
+	if r.err == nil {
+		r.timeout = d
+	}
+
+	return r
+}
+
+// GetTimeout returns the timeout of the route or the DefaultTimeout of the router,
+// zero if the route has no timeout.
+func (r *Route) GetTimeout() time.Duration {
+	switch {
+	case r.timeout < 0:
+		return 0
+	case r.timeout == 0 && r.router != nil:
+		return r.router.DefaultTimeout
+	}
+
+	return r.timeout
+}
+
+// timeoutHandler calls the handler with a deadline on the context of the request.
+func (r *Router) timeoutHandler(handler http.Handler, timeout time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if isUpgradeRequest(req) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+
+		tw := &timeoutWriter{w: w, header: make(http.Header)}
+		done := make(chan struct{})
+		panicked := make(chan handlerPanic, 1)
+		go func() {
+			defer func() {
+				recovered := recover()
+				if recovered != nil {
+					panicked <- handlerPanic{recovered: recovered, stack: debug.Stack()}
+				}
+
+				tw.mu.Lock()
+				tw.finished = ctx.Err() == nil
+				tw.mu.Unlock()
+
+				if recovered == nil {
+					close(done)
+				}
+			}()
+			handler.ServeHTTP(tw, req)
+		}()
+
+		select {
+		case p := <-panicked:
+			p.repanic()
+		case <-done:
+		case <-ctx.Done():
+		}
+
+		tw.mu.Lock()
+		if tw.wroteHeader || tw.finished {
+			// the handler started the response or returned before the deadline
+			tw.mu.Unlock()
+			select {
+			case p := <-panicked:
+				p.repanic()
+			case <-done:
+				tw.finish()
+			}
+			return
+		}
+		tw.timedOut = true
+		tw.mu.Unlock()
+
+		go r.reportLatePanic(tw, req, panicked, done)
+
+		if ctx.Err() == context.DeadlineExceeded {
+			r.handleError(w, req, NewHTTPError(http.StatusServiceUnavailable, "", fmt.Errorf("mux: handler timeout after %s: %w", timeout, ctx.Err())))
+		}
+	})
+}
+
+// handlerPanic is a panic recovered in the goroutine of the handler.
+type handlerPanic struct {
+	recovered interface{}
+	stack     []byte
+}
+
+// repanic raises the panic again in the goroutine of the request,
+// it's raised as *handlerPanic to keep the stack of the handler, which
+// recoverPanic reports. http.ErrAbortHandler is raised as it is.
+func (p handlerPanic) repanic() {
+	if p.recovered == http.ErrAbortHandler {
+		panic(p.recovered)
+	}
+	panic(&p)
+}
+
+// String returns the panic with the stack of the handler,
+// it's logged by the server if the panic isn't recovered.
+func (p *handlerPanic) String() string {
+	return fmt.Sprintf("%v\n%s", p.recovered, p.stack)
+}
+
+// reportLatePanic reports a panic of the handler after the request timed out,
+// the response is already written, so it's only passed to the Observer and
+// the PanicHandler or logged.
+func (r *Router) reportLatePanic(w http.ResponseWriter, req *http.Request, panicked <-chan handlerPanic, done <-chan struct{}) {
+	select {
+	case p := <-panicked:
+		if p.recovered != http.ErrAbortHandler {
+			r.reportPanic(w, req, p.recovered, p.stack)
+		}
+	case <-done:
+	}
+}
+
+// timeoutWriter passes the response of the handler to w until the request timed out.
+// The handler gets its own header, which is copied to w with the status code.
+type timeoutWriter struct {
+	w           http.ResponseWriter
+	header      http.Header
+	mu          sync.Mutex
+	wroteHeader bool
+	timedOut    bool
+	// finished is true if the handler returned before the deadline
+	finished bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+
+	tw.writeHeader(code)
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+
+	if !tw.wroteHeader {
+		tw.writeHeader(http.StatusOK)
+	}
+
+	return tw.w.Write(b)
+}
+
+// Flush implements http.Flusher if the underlying writer supports it.
+func (tw *timeoutWriter) Flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	flusher, ok := tw.w.(http.Flusher)
+	if !ok || tw.timedOut {
+		return
+	}
+
+	if !tw.wroteHeader {
+		tw.writeHeader(http.StatusOK)
+	}
+	flusher.Flush()
+}
+
+// Hijack implements http.Hijacker if the underlying writer supports it,
+// a hijacked connection isn't answered with 503 after the timeout.
+func (tw *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return nil, nil, http.ErrHandlerTimeout
+	}
+
+	hijacker, ok := tw.w.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("mux: %T does not implement http.Hijacker", tw.w)
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		tw.wroteHeader = true
+	}
+
+	return conn, rw, err
+}
+
+// Unwrap returns the underlying writer, see http.ResponseController
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.w
+}
+
+func (tw *timeoutWriter) writeHeader(code int) {
+	tw.copyHeader()
+	tw.wroteHeader = true
+	tw.w.WriteHeader(code)
+}
+
+// finish copies the header if the handler returned without writing.
+func (tw *timeoutWriter) finish() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if !tw.wroteHeader {
+		tw.copyHeader()
+	}
+}
+
+func (tw *timeoutWriter) copyHeader() {
+	dst := tw.w.Header()
+	for key, values := range tw.header {
+		dst[key] = values
+	}
+}
+
+// isUpgradeRequest returns true if the Connection header contains the upgrade token.
+func isUpgradeRequest(req *http.Request) bool {
+	for _, value := range req.Header.Values("Connection") {
+		for _, token := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
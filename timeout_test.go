@@ -0,0 +1,231 @@
+package mux
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+
+	writeErrs := make(chan error, 1)
+
+	tests := []struct {
+		title    string
+		path     string
+		status   int
+		expected string
+	}{
+		{title: "Fast handler", path: "/fast", status: http.StatusOK, expected: "fast"},
+		{title: "Slow handler", path: "/slow", status: http.StatusServiceUnavailable},
+		{title: "Started handler", path: "/stream", status: http.StatusAccepted, expected: "started finished"},
+		{title: "Default timeout", path: "/default", status: http.StatusServiceUnavailable},
+		{title: "Disabled timeout", path: "/disabled", status: http.StatusOK, expected: "disabled"},
+	}
+
+	r := Classic()
+	r.DefaultTimeout = 20 * time.Millisecond
+
+	r.Get("/fast", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Handler", "fast")
+		w.Write([]byte("fast"))
+	}).(*Route).Timeout(time.Second)
+
+	r.Get("/slow", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Handler", "slow")
+		<-r.Context().Done()
+		time.Sleep(10 * time.Millisecond)
+		_, err := w.Write([]byte("slow"))
+		writeErrs <- err
+	}).(*Route).Timeout(10 * time.Millisecond)
+
+	r.Get("/stream", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("started"))
+		<-r.Context().Done()
+		w.Write([]byte(" finished"))
+	}).(*Route).Timeout(10 * time.Millisecond)
+
+	r.Get("/default", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	r.Get("/disabled", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); !ok {
+			w.Write([]byte("disabled"))
+		}
+	}).(*Route).Timeout(-1)
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost"+test.path, nil)
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Code != test.status {
+				t.Errorf("Unexpected status code (Expected: %d, Actucal: %d)", test.status, res.Code)
+			}
+
+			if test.expected != "" && res.Body.String() != test.expected {
+				t.Errorf("Unexpected body (Expected: %s, Actucal: %s)", test.expected, res.Body.String())
+			}
+
+			if test.status == http.StatusServiceUnavailable && res.Header().Get("X-Handler") != "" {
+				t.Errorf("Unexpected header of the handler (%s)", res.Header().Get("X-Handler"))
+			}
+		})
+	}
+
+	if err := <-writeErrs; err != http.ErrHandlerTimeout {
+		t.Errorf("Unexpected error of the write after the timeout (%v)", err)
+	}
+}
+
+func TestTimeoutHeader(t *testing.T) {
+	r := Classic()
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Handler", "header")
+	}).(*Route).Timeout(time.Second)
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "http://localhost/", nil))
+
+	if res.Header().Get("X-Handler") != "header" {
+		t.Errorf("Unexpected header (%s)", res.Header().Get("X-Handler"))
+	}
+}
+
+func TestTimeoutPanic(t *testing.T) {
+	r := Classic()
+	r.RecoverPanics = true
+	r.PanicHandler = func(w http.ResponseWriter, req *http.Request, recovered interface{}, stack []byte) {}
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}).(*Route).Timeout(time.Second)
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "http://localhost/", nil))
+
+	if res.Code != http.StatusInternalServerError {
+		t.Errorf("Unexpected status code (%d)", res.Code)
+	}
+}
+
+func TestTimeoutPanicStack(t *testing.T) {
+	var reported interface{}
+	var stack []byte
+
+	r := Classic()
+	r.RecoverPanics = true
+	r.PanicHandler = func(w http.ResponseWriter, req *http.Request, recovered interface{}, s []byte) {
+		reported, stack = recovered, s
+	}
+	r.Get("/", panickingHandler).(*Route).Timeout(time.Second)
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "http://localhost/", nil))
+
+	if reported != "boom" {
+		t.Errorf("Unexpected panic (Expected: %v, Actucal: %v)", "boom", reported)
+	}
+
+	if !strings.Contains(string(stack), "panickingHandler") {
+		t.Errorf("Expected the stack of the handler (%s)", stack)
+	}
+}
+
+func panickingHandler(w http.ResponseWriter, r *http.Request) {
+	panic("boom")
+}
+
+func TestTimeoutLatePanic(t *testing.T) {
+	reported := make(chan interface{}, 1)
+
+	r := Classic()
+	r.PanicHandler = func(w http.ResponseWriter, req *http.Request, recovered interface{}, stack []byte) {
+		reported <- recovered
+	}
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		panic("late")
+	}).(*Route).Timeout(10 * time.Millisecond)
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "http://localhost/", nil))
+
+	if res.Code != http.StatusServiceUnavailable {
+		t.Errorf("Unexpected status code (%d)", res.Code)
+	}
+
+	select {
+	case recovered := <-reported:
+		if recovered != "late" {
+			t.Errorf("Unexpected panic (%v)", recovered)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the panic after the timeout to be reported")
+	}
+}
+
+func TestTimeoutWriterPassThrough(t *testing.T) {
+	r := Classic()
+	r.DefaultTimeout = time.Second
+	r.Get("/hijack", func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer conn.Close()
+
+		buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 6\r\nConnection: close\r\n\r\nhijack")
+		buf.Flush()
+	})
+	r.Get("/deadline", func(w http.ResponseWriter, r *http.Request) {
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(time.Second)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("deadline"))
+	})
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	for _, path := range []string{"/hijack", "/deadline"} {
+		res, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err.Error())
+		}
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusOK || string(body) != path[1:] {
+			t.Errorf("Unexpected response (Status code: %d, Body: %s)", res.StatusCode, body)
+		}
+	}
+}
+
+func TestGetTimeout(t *testing.T) {
+	r := Classic()
+	r.DefaultTimeout = time.Second
+
+	tests := []struct {
+		timeout  time.Duration
+		expected time.Duration
+	}{
+		{timeout: 0, expected: time.Second},
+		{timeout: 5 * time.Second, expected: 5 * time.Second},
+		{timeout: -1, expected: 0},
+	}
+
+	for _, test := range tests {
+		route := r.NewRoute().(*Route).Timeout(test.timeout)
+		if route.GetTimeout() != test.expected {
+			t.Errorf("Unexpected timeout (Expected: %s, Actucal: %s)", test.expected, route.GetTimeout())
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package mux
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCertReloadInterval is the interval to check the certificate files for changes.
+const DefaultCertReloadInterval = time.Minute
+
+// TLSOptions configures the TLS of Router.ServeTLS
+type TLSOptions struct {
+	// Files of the certificate and the key, they are reloaded on change.
+	CertFile string
+	KeyFile  string
+	// Base config, e.g. for the cipher suites or the certificates.
+	Config *tls.Config
+	// Interval to check the certificate files for changes, DefaultCertReloadInterval if zero.
+	ReloadInterval time.Duration
+	// TCP address of a plain HTTP listener which redirects to HTTPS, e.g. ":80"
+	// No redirect listener is started if it's empty.
+	RedirectAddr string
+}
+
+// ListenAndServeTLS listens on the TCP network address addr
+// and then calls ServeTLS with handler to handle requests
+// on incoming TLS connections. The callback gets the errors
+// of the routes or of the server, see ServeTLS. Unlike ServeTLS it
+// doesn't handle signals, the process keeps their default behaviour.
+func (r *Router) ListenAndServeTLS(port string, certFile string, keyFile string, callback func(errs []error)) {
+	err := r.ServeTLS(context.Background(), ServeOptions{Addr: port, noSignals: true}, TLSOptions{CertFile: certFile, KeyFile: keyFile})
+	passErrors(err, callback)
+}
+
+// ServeTLS is the TLS counterpart of Serve. The certificate files of tlsOpts
+// are watched and reloaded on change without restarting the server.
+//
+//     err := r.ServeTLS(ctx, mux.ServeOptions{Addr: ":443"}, mux.TLSOptions{
+//         CertFile:     "cert.pem",
+//         KeyFile:      "key.pem",
+//         RedirectAddr: ":80",
+//     })
+//
+// With RedirectAddr all plain HTTP requests are redirected to HTTPS,
+// so routes restricted by Schemes("https") are reachable.
+func (r *Router) ServeTLS(ctx context.Context, opts ServeOptions, tlsOpts TLSOptions) error {
+	if err := r.prepare(); err != nil {
+		return err
+	}
+
+	config, reloader, err := tlsOpts.config()
+	if err != nil {
+		return err
+	}
+
+	if opts.Addr == "" {
+		opts.Addr = ":https"
+	}
+
+	server := r.newServer(opts)
+	server.TLSConfig = config
+
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if reloader != nil {
+		go reloader.Watch(ctx, tlsOpts.ReloadInterval)
+	}
+
+	if tlsOpts.RedirectAddr == "" {
+		return serveGracefully(ctx, server, opts, func() error {
+			return server.ServeTLS(listener, "", "")
+		})
+	}
+
+	redirectListener, err := net.Listen("tcp", tlsOpts.RedirectAddr)
+	if err != nil {
+		listener.Close()
+		return err
+	}
+
+	redirectServer := r.newServer(opts)
+	redirectServer.Handler = HTTPSRedirectHandler(listener.Addr().(*net.TCPAddr).Port)
+
+	redirected := make(chan error, 1)
+	go func() {
+		redirected <- serveGracefully(ctx, redirectServer, opts, func() error {
+			return redirectServer.Serve(redirectListener)
+		})
+	}()
+
+	err = serveGracefully(ctx, server, opts, func() error {
+		return server.ServeTLS(listener, "", "")
+	})
+
+	cancel()
+	if redirectErr := <-redirected; err == nil {
+		err = redirectErr
+	}
+
+	return err
+}
+
+// config returns the TLS config and the reloader of the certificate files.
+func (o TLSOptions) config() (*tls.Config, *CertReloader, error) {
+	config := &tls.Config{}
+	if o.Config != nil {
+		config = o.Config.Clone()
+	}
+
+	if o.CertFile == "" && o.KeyFile == "" {
+		if len(config.Certificates) == 0 && config.GetCertificate == nil {
+			return nil, nil, errors.New("mux: TLS needs a certificate")
+		}
+		return config, nil, nil
+	}
+
+	reloader, err := NewCertReloader(o.CertFile, o.KeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config.GetCertificate = reloader.GetCertificate
+	return config, reloader, nil
+}
+
+// HTTPSRedirectHandler redirects all requests to the same URL with HTTPS.
+// The port is omitted if it's 443 or zero.
+func HTTPSRedirectHandler(port int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		host := req.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+
+		switch {
+		case port != 0 && port != 443:
+			host = net.JoinHostPort(host, strconv.Itoa(port))
+		case strings.Contains(host, ":"):
+			host = "[" + host + "]"
+		}
+
+		status := http.StatusPermanentRedirect
+		if req.Method == http.MethodGet || req.Method == http.MethodHead {
+			status = http.StatusMovedPermanently
+		}
+
+		http.Redirect(w, req, "https://"+host+req.URL.RequestURI(), status)
+	})
+}
+
+// CertReloader holds a certificate which is reloaded if the files change.
+// GetCertificate can be used as tls.Config.GetCertificate
+type CertReloader struct {
+	certFile string
+	keyFile  string
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	modTime  time.Time
+}
+
+// NewCertReloader loads the certificate, see Reload and Watch
+func NewCertReloader(certFile string, keyFile string) (*CertReloader, error) {
+	reloader := &CertReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	if _, err := reloader.Reload(); err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+// GetCertificate returns the current certificate.
+func (c *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cert, nil
+}
+
+// Reload loads the certificate if the files changed since the last load.
+// It reports if the certificate was loaded, the old certificate is kept on errors.
+func (c *CertReloader) Reload() (bool, error) {
+	modTime, err := c.lastModified()
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.RLock()
+	unchanged := c.cert != nil && modTime.Equal(c.modTime)
+	c.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cert = &cert
+	c.modTime = modTime
+	return true, nil
+}
+
+// Watch calls Reload in the interval until the context is cancelled.
+func (c *CertReloader) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultCertReloadInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := c.Reload(); err != nil {
+				log.Printf("mux: reload of certificate %s failed: %v", c.certFile, err)
+			}
+		}
+	}
+}
+
+// lastModified returns the latest modification time of the files.
+func (c *CertReloader) lastModified() (time.Time, error) {
+	var modTime time.Time
+	for _, filename := range []string{c.certFile, c.keyFile} {
+		info, err := os.Stat(filename)
+		if err != nil {
+			return modTime, err
+		}
+
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+
+	return modTime, nil
+}
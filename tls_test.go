@@ -0,0 +1,218 @@
+package mux
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTestCert writes a self-signed certificate for localhost.
+func writeTestCert(t *testing.T, dir string, commonName string, modTime time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	os.Chtimes(certFile, modTime, modTime)
+	os.Chtimes(keyFile, modTime, modTime)
+
+	return certFile, keyFile
+}
+
+func certCommonName(t *testing.T, cert *tls.Certificate) string {
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	return parsed.Subject.CommonName
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCert(t, dir, "first", time.Now().Add(-time.Minute))
+
+	reloader, err := NewCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	if reloaded, err := reloader.Reload(); reloaded || err != nil {
+		t.Errorf("Unexpected reload of unchanged files (%v, %v)", reloaded, err)
+	}
+
+	writeTestCert(t, dir, "second", time.Now())
+	if reloaded, err := reloader.Reload(); !reloaded || err != nil {
+		t.Errorf("Expected a reload of changed files (%v, %v)", reloaded, err)
+	}
+
+	cert, _ := reloader.GetCertificate(nil)
+	if name := certCommonName(t, cert); name != "second" {
+		t.Errorf("Unexpected certificate (%s)", name)
+	}
+
+	os.WriteFile(certFile, []byte("broken"), 0600)
+	os.Chtimes(certFile, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+	if _, err := reloader.Reload(); err == nil {
+		t.Error("Expected a error")
+	}
+
+	cert, _ = reloader.GetCertificate(nil)
+	if name := certCommonName(t, cert); name != "second" {
+		t.Errorf("Unexpected certificate after failed reload (%s)", name)
+	}
+}
+
+func TestHTTPSRedirectHandler(t *testing.T) {
+
+	tests := []struct {
+		method     string
+		url        string
+		port       int
+		statusCode int
+		location   string
+	}{
+		{method: http.MethodGet, url: "http://localhost/user/42?page=1", port: 443, statusCode: http.StatusMovedPermanently, location: "https://localhost/user/42?page=1"},
+		{method: http.MethodGet, url: "http://localhost:8080/", port: 8443, statusCode: http.StatusMovedPermanently, location: "https://localhost:8443/"},
+		{method: http.MethodPost, url: "http://localhost/user", port: 0, statusCode: http.StatusPermanentRedirect, location: "https://localhost/user"},
+		{method: http.MethodGet, url: "http://[::1]:80/user", port: 443, statusCode: http.StatusMovedPermanently, location: "https://[::1]/user"},
+		{method: http.MethodGet, url: "http://[::1]/user", port: 8443, statusCode: http.StatusMovedPermanently, location: "https://[::1]:8443/user"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			req, _ := http.NewRequest(test.method, test.url, nil)
+			res := httptest.NewRecorder()
+			HTTPSRedirectHandler(test.port).ServeHTTP(res, req)
+
+			if res.Code != test.statusCode || res.Header().Get("Location") != test.location {
+				t.Errorf("Unexpected response (Status code: %d, Location: %s)", res.Code, res.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestServeTLS(t *testing.T) {
+	certFile, keyFile := writeTestCert(t, t.TempDir(), "localhost", time.Now())
+
+	addrs := make([]string, 2)
+	for k := range addrs {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Unexpected error (%s)", err.Error())
+		}
+		addrs[k] = listener.Addr().String()
+		listener.Close()
+	}
+
+	r := Classic()
+	r.Get("/secure", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secure"))
+	}).(*Route).Schemes("https")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- r.ServeTLS(ctx, ServeOptions{Addr: addrs[0]}, TLSOptions{CertFile: certFile, KeyFile: keyFile, RedirectAddr: addrs[1]})
+	}()
+
+	for i := 0; i < 50; i++ {
+		if conn, err := net.Dial("tcp", addrs[1]); err == nil {
+			conn.Close()
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+	}
+
+	res, err := client.Get("http://" + addrs[1] + "/secure")
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK || string(body) != "secure" || res.TLS == nil {
+		t.Errorf("Unexpected response (Status code: %d, Body: %s)", res.StatusCode, body)
+	}
+
+	cancel()
+	if err := <-served; err != nil {
+		t.Errorf("Unexpected error (%s)", err.Error())
+	}
+}
+
+func TestServeTLSFail(t *testing.T) {
+	r := Classic()
+
+	if err := r.ServeTLS(context.Background(), ServeOptions{Addr: "127.0.0.1:0"}, TLSOptions{}); err == nil {
+		t.Error("Expected a error")
+	}
+
+	if err := r.ServeTLS(context.Background(), ServeOptions{Addr: "127.0.0.1:0"}, TLSOptions{CertFile: "does-not-exist.pem", KeyFile: "does-not-exist.pem"}); err == nil {
+		t.Error("Expected a error")
+	}
+}
+
+func TestListenAndServeTLSFail(t *testing.T) {
+	r := Classic()
+
+	var errs []error
+	r.ListenAndServeTLS("127.0.0.1:0", filepath.Join(t.TempDir(), "missing.pem"), filepath.Join(t.TempDir(), "missing.key"), func(e []error) {
+		errs = e
+	})
+
+	if len(errs) != 1 {
+		t.Errorf("Unexpected errors (%v)", errs)
+	}
+
+	r.Get("/echo", func(w http.ResponseWriter, r *http.Request) {}).SetError(errors.New("Test error"))
+	r.ListenAndServeTLS("127.0.0.1:0", "", "", func(e []error) {
+		errs = e
+	})
+
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "Test error") {
+		t.Errorf("Unexpected errors (%v)", errs)
+	}
+}
@@ -0,0 +1,224 @@
+package mux
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const traceParentHeader = "traceparent"
+
+// TraceID is the id of a W3C trace context trace.
+type TraceID [16]byte
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// MarshalText encodes the id as lower case hex.
+func (id TraceID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// SpanID is the id of a W3C trace context span.
+type SpanID [8]byte
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// MarshalText encodes the id as lower case hex.
+func (id SpanID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// Span describes a served request, see Router.SpanExporter
+type Span struct {
+	TraceID  TraceID `json:"traceId"`
+	SpanID   SpanID  `json:"spanId"`
+	ParentID SpanID  `json:"parentSpanId"`
+	Sampled  bool    `json:"sampled"`
+	// Name is the method and the path of the matched route, e.g. GET /user/:number
+	Name   string    `json:"name"`
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	Route  string    `json:"route,omitempty"`
+	Status int       `json:"status"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	// MatchDuration is the time spent in the matchers.
+	MatchDuration time.Duration `json:"matchDuration"`
+	// HandlerDuration is the time spent in the handler of the matched route.
+	HandlerDuration time.Duration `json:"handlerDuration"`
+}
+
+// TraceParent returns the traceparent header value to propagate the span,
+// e.g. to outgoing requests of the handler.
+func (s *Span) TraceParent() string {
+	flags := "00"
+	if s.Sampled {
+		flags = "01"
+	}
+
+	return fmt.Sprintf("00-%s-%s-%s", s.TraceID, s.SpanID, flags)
+}
+
+// SpanExporter gets the finished and sampled spans.
+type SpanExporter interface {
+	ExportSpan(span *Span) error
+}
+
+// newSpan starts the span of the request, it continues the trace of
+// the traceparent header or starts a new sampled trace.
+func newSpan(req *http.Request, start time.Time) *Span {
+	span := &Span{
+		Name:   req.Method,
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Start:  start,
+	}
+
+	if traceID, parentID, sampled, ok := parseTraceParent(req.Header.Get(traceParentHeader)); ok {
+		span.TraceID, span.ParentID, span.Sampled = traceID, parentID, sampled
+	} else {
+		rand.Read(span.TraceID[:])
+		span.Sampled = true
+	}
+
+	rand.Read(span.SpanID[:])
+	return span
+}
+
+// parseTraceParent parses the traceparent header, see https://www.w3.org/TR/trace-context/
+func parseTraceParent(value string) (traceID TraceID, parentID SpanID, sampled bool, ok bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return traceID, parentID, false, false
+	}
+
+	// Version 00 has exactly four parts, future versions may append parts.
+	if parts[0] == "00" && len(parts) != 4 {
+		return traceID, parentID, false, false
+	}
+
+	version, err := hex.DecodeString(parts[0])
+	if err != nil || len(version) != 1 {
+		return traceID, parentID, false, false
+	}
+
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return traceID, parentID, false, false
+	}
+
+	if _, err := hex.Decode(traceID[:], []byte(parts[1])); err != nil || traceID == (TraceID{}) {
+		return traceID, parentID, false, false
+	}
+
+	if _, err := hex.Decode(parentID[:], []byte(parts[2])); err != nil || parentID == (SpanID{}) {
+		return traceID, parentID, false, false
+	}
+
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return traceID, parentID, false, false
+	}
+
+	return traceID, parentID, flags[0]&0x01 == 0x01, true
+}
+
+// finishSpan completes the span and hands it to the exporter if it's sampled.
+func (r *Router) finishSpan(span *Span, req *http.Request, status int) {
+	span.End = time.Now()
+	span.Status = status
+
+	if route := CurrentRoute(req); route != nil {
+		span.Route = route.GetPath()
+		span.Name = span.Method + " " + span.Route
+	}
+
+	if !span.Sampled {
+		return
+	}
+
+	if err := r.SpanExporter.ExportSpan(span); err != nil {
+		log.Printf("mux: export of span %s failed: %v", span.SpanID, err)
+	}
+}
+
+// MemoryExporter keeps the exported spans in memory, e.g. for tests.
+type MemoryExporter struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+func (e *MemoryExporter) ExportSpan(span *Span) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, span)
+	return nil
+}
+
+// Spans returns the exported spans in order.
+func (e *MemoryExporter) Spans() []*Span {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]*Span(nil), e.spans...)
+}
+
+// Reset removes all exported spans.
+func (e *MemoryExporter) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = nil
+}
+
+// JSONLinesExporter writes each span as a JSON object in a line.
+type JSONLinesExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLinesExporter returns an exporter which writes to w.
+func NewJSONLinesExporter(w io.Writer) *JSONLinesExporter {
+	return &JSONLinesExporter{w: w}
+}
+
+// OpenJSONLinesExporter returns an exporter which appends to the file,
+// the file is created if it doesn't exist. See Close
+func OpenJSONLinesExporter(filename string) (*JSONLinesExporter, error) {
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJSONLinesExporter(file), nil
+}
+
+func (e *JSONLinesExporter) ExportSpan(span *Span) error {
+	line, err := json.Marshal(span)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err = e.w.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the underlying writer if it's a io.Closer
+func (e *JSONLinesExporter) Close() error {
+	if closer, ok := e.w.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package mux
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseTraceParent(t *testing.T) {
+
+	tests := []struct {
+		value   string
+		ok      bool
+		sampled bool
+	}{
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", ok: true, sampled: true},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", ok: true, sampled: false},
+		{value: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future", ok: true, sampled: true},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future", ok: false},
+		{value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", ok: false},
+		{value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", ok: false},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", ok: false},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01", ok: false},
+		{value: "00-xxf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", ok: false},
+		{value: "", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			traceID, parentID, sampled, ok := parseTraceParent(test.value)
+			if ok != test.ok || sampled != test.sampled {
+				t.Fatalf("Unexpected result (ok: %v, sampled: %v)", ok, sampled)
+			}
+
+			if ok && (traceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || parentID.String() != "00f067aa0ba902b7") {
+				t.Errorf("Unexpected ids (%s, %s)", traceID, parentID)
+			}
+		})
+	}
+}
+
+func TestTracing(t *testing.T) {
+	exporter := &MemoryExporter{}
+	r := Classic()
+	r.SpanExporter = exporter
+
+	var current []*Span
+	r.Get("/user/:number", func(w http.ResponseWriter, r *http.Request) {
+		current = append(current, GetSpan(r))
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/user/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://localhost/article", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://localhost/user/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("Unexpected count of spans (%d)", len(spans))
+	}
+
+	span := spans[0]
+	if len(current) != 2 || span != current[0] || current[1].Sampled {
+		t.Errorf("Expected the span in the context of the handler")
+	}
+
+	if span.Name != "GET /user/:number" || span.Route != "/user/:number" || span.Status != http.StatusOK || span.Path != "/user/42" {
+		t.Errorf("Unexpected span (%v)", span)
+	}
+
+	if span.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || span.ParentID.String() != "00f067aa0ba902b7" || span.SpanID == (SpanID{}) {
+		t.Errorf("Unexpected ids (%s, %s, %s)", span.TraceID, span.ParentID, span.SpanID)
+	}
+
+	if !strings.HasPrefix(span.TraceParent(), "00-4bf92f3577b34da6a3ce929d0e0e4736-"+span.SpanID.String()) || !strings.HasSuffix(span.TraceParent(), "-01") {
+		t.Errorf("Unexpected traceparent (%s)", span.TraceParent())
+	}
+
+	if span.End.Before(span.Start) || span.MatchDuration <= 0 || span.HandlerDuration <= 0 {
+		t.Errorf("Unexpected durations (%v)", span)
+	}
+
+	notFound := spans[1]
+	if notFound.Name != http.MethodGet || notFound.Status != http.StatusNotFound || notFound.TraceID == (TraceID{}) || notFound.ParentID != (SpanID{}) {
+		t.Errorf("Unexpected span (%v)", notFound)
+	}
+
+	exporter.Reset()
+	if len(exporter.Spans()) != 0 {
+		t.Error("Expected no spans after reset")
+	}
+}
+
+func TestJSONLinesExporter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	exporter := NewJSONLinesExporter(buf)
+
+	span := &Span{Name: "GET /user/:number", Status: http.StatusOK, Sampled: true}
+	span.TraceID[0], span.SpanID[0] = 0xab, 0xcd
+
+	for i := 0; i < 2; i++ {
+		if err := exporter.ExportSpan(span); err != nil {
+			t.Fatalf("Unexpected error (%s)", err.Error())
+		}
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Unexpected count of lines (%d)", len(lines))
+	}
+
+	record := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	if record["traceId"] != "ab000000000000000000000000000000" || record["spanId"] != "cd00000000000000" || record["name"] != "GET /user/:number" {
+		t.Errorf("Unexpected record (%v)", record)
+	}
+}
+
+func TestOpenJSONLinesExporter(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "spans.jsonl")
+
+	exporter, err := OpenJSONLinesExporter(filename)
+	if err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	exporter.ExportSpan(&Span{Name: "GET"})
+	if err := exporter.Close(); err != nil {
+		t.Fatalf("Unexpected error (%s)", err.Error())
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil || !strings.Contains(string(content), `"name":"GET"`) {
+		t.Errorf("Unexpected content (%s, %v)", content, err)
+	}
+}
@@ -0,0 +1,121 @@
+package mux
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+)
+
+// responseWriter records the status code and the count of written bytes.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	written     int64
+	wroteHeader bool
+}
+
+// newResponseWriter wraps w, an already wrapped writer is returned as it is.
+func newResponseWriter(w http.ResponseWriter) *responseWriter {
+	if rw, ok := w.(*responseWriter); ok {
+		return rw
+	}
+
+	return &responseWriter{ResponseWriter: w}
+}
+
+// WriteHeader writes the header, informational (1xx) headers are passed
+// through without becoming the status of the response.
+func (w *responseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+
+	if code >= 100 && code <= 199 && code != http.StatusSwitchingProtocols {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	w.status = code
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	n, err := w.ResponseWriter.Write(b)
+	w.written += int64(n)
+	return n, err
+}
+
+// ReadFrom implements io.ReaderFrom, it keeps the fast path of the
+// underlying writer, e.g. sendfile of files.
+func (w *responseWriter) ReadFrom(src io.Reader) (int64, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	var n int64
+	var err error
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		n, err = rf.ReadFrom(src)
+	} else {
+		n, err = io.Copy(w.ResponseWriter, src)
+	}
+
+	w.written += n
+	return n, err
+}
+
+// Status returns the written status code, 200 if nothing was written yet.
+func (w *responseWriter) Status() int {
+	if !w.wroteHeader {
+		return http.StatusOK
+	}
+
+	return w.status
+}
+
+// Written returns the count of written bytes of the body.
+func (w *responseWriter) Written() int64 {
+	return w.written
+}
+
+// Flush implements http.Flusher if the underlying writer supports it.
+func (w *responseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		if !w.wroteHeader {
+			w.WriteHeader(http.StatusOK)
+		}
+		flusher.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker if the underlying writer supports it.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("mux: %T does not implement http.Hijacker", w.ResponseWriter)
+	}
+
+	return hijacker.Hijack()
+}
+
+// Push implements http.Pusher if the underlying writer supports it.
+func (w *responseWriter) Push(target string, opts *http.PushOptions) error {
+	pusher, ok := w.ResponseWriter.(http.Pusher)
+	if !ok {
+		return http.ErrNotSupported
+	}
+
+	return pusher.Push(target, opts)
+}
+
+// Unwrap returns the underlying writer, see http.ResponseController
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
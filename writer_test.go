@@ -0,0 +1,37 @@
+package mux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResponseWriterInformational(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := newResponseWriter(rec)
+
+	w.WriteHeader(http.StatusEarlyHints)
+	if w.wroteHeader || w.Status() != http.StatusOK {
+		t.Fatalf("Unexpected final status (%d)", w.Status())
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if !w.wroteHeader || w.Status() != http.StatusCreated {
+		t.Errorf("Unexpected status (%d)", w.Status())
+	}
+}
+
+func TestResponseWriterReadFrom(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := newResponseWriter(rec)
+
+	n, err := w.ReadFrom(strings.NewReader("hello"))
+	if err != nil || n != 5 || w.Written() != 5 || rec.Body.String() != "hello" {
+		t.Errorf("Unexpected result (%d, %v, %s)", n, err, rec.Body.String())
+	}
+
+	if err := w.Push("/app.js", nil); err != http.ErrNotSupported {
+		t.Errorf("Unexpected push error (%v)", err)
+	}
+}